@@ -21,6 +21,8 @@ func NewAnteHandler(
 	sigGasConsumer ante.SignatureVerificationGasConsumer,
 	channelKeeper *ibckeeper.Keeper,
 	paramKeeper paramkeeper.Keeper,
+	maxGasPrice sdk.DecCoin,
+	feeGrantPriority FeeGrantPriorityPolicy,
 	msgVersioningGateKeeper *MsgVersioningGateKeeper,
 ) sdk.AnteHandler {
 	return sdk.ChainAnteDecorators(
@@ -45,7 +47,7 @@ func NewAnteHandler(
 		ante.NewConsumeGasForTxSizeDecorator(accountKeeper),
 		// Ensure the feepayer (fee granter or first signer) has enough funds to pay for the tx.
 		// Side effect: deducts fees from the fee payer. Sets the tx priority in context.
-		ante.NewDeductFeeDecorator(accountKeeper, bankKeeper, feegrantKeeper, ValidateTxFeeWrapper(paramKeeper)),
+		ante.NewDeductFeeDecorator(accountKeeper, bankKeeper, feegrantKeeper, ValidateTxFeeWrapper(paramKeeper, maxGasPrice, feeGrantPriority)),
 		// Set public keys in the context for fee-payer and all signers.
 		// Contract: must be called before all signature verification decorators.
 		ante.NewSetPubKeyDecorator(accountKeeper),
@@ -78,10 +80,14 @@ func NewAnteHandler(
 
 var DefaultSigVerificationGasConsumer = ante.DefaultSigVerificationGasConsumer
 
-// The purpose of this wrapper is to enable the passing of an additional paramKeeper parameter
-// whilst still satisfying the ante.TxFeeChecker type.
-func ValidateTxFeeWrapper(paramKeeper paramkeeper.Keeper) ante.TxFeeChecker {
+// The purpose of this wrapper is to enable the passing of the additional paramKeeper,
+// maxGasPrice and feeGrantPriority parameters whilst still satisfying the ante.TxFeeChecker
+// type. The minfee and staking subspaces are resolved once here rather than for every
+// transaction.
+func ValidateTxFeeWrapper(paramKeeper paramkeeper.Keeper, maxGasPrice sdk.DecCoin, feeGrantPriority FeeGrantPriorityPolicy) ante.TxFeeChecker {
+	subspace := getMinFeeSubspace(paramKeeper)
+	stakingSubspace := getStakingSubspace(paramKeeper)
 	return func(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, int64, error) {
-		return ValidateTxFee(ctx, tx, paramKeeper)
+		return validateTxFee(ctx, tx, subspace, stakingSubspace, maxGasPrice, feeGrantPriority)
 	}
 }
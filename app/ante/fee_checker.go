@@ -1,99 +1,309 @@
 package ante
 
 import (
+	"fmt"
+	gomath "math"
+
 	errors "cosmossdk.io/errors"
 	"cosmossdk.io/math"
 	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
 	v1 "github.com/celestiaorg/celestia-app/v2/pkg/appconsts/v1"
+	blobtypes "github.com/celestiaorg/celestia-app/v2/x/blob/types"
 	"github.com/celestiaorg/celestia-app/v2/x/minfee"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerror "github.com/cosmos/cosmos-sdk/types/errors"
 	params "github.com/cosmos/cosmos-sdk/x/params/keeper"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
+// FlagMaxGasPrice is the node config key for the maximum gas price, i.e.
+// "100utia", that a node accepts into its mempool.
+const FlagMaxGasPrice = "max-gas-price"
+
+// FlagFeeGrantPriority is the node config key for the FeeGrantPriorityPolicy
+// applied to transactions in its mempool.
+const FlagFeeGrantPriority = "fee-grant-priority"
+
+// FeeGrantPriorityPolicy determines the mempool priority of transactions whose
+// fee is paid by a fee granter. Like the maximum gas price, it is node specific
+// and doesn't affect consensus.
+type FeeGrantPriorityPolicy string
+
+const (
+	// FeeGrantPriorityFee computes the priority of fee grant transactions from
+	// their gas price like any other transaction. The fee is deducted in full
+	// from the granter so the priority reflects what the network earns. This
+	// is the default.
+	FeeGrantPriorityFee FeeGrantPriorityPolicy = "fee"
+	// FeeGrantPriorityLowest assigns fee grant transactions a priority of zero
+	// so that they never displace transactions paid by their signers, for
+	// example when a granter subsidizes a large amount of load.
+	FeeGrantPriorityLowest FeeGrantPriorityPolicy = "lowest"
+)
+
+// ParseFeeGrantPriorityPolicy returns the policy with the given name. An empty
+// name returns the default FeeGrantPriorityFee.
+func ParseFeeGrantPriorityPolicy(name string) (FeeGrantPriorityPolicy, error) {
+	switch FeeGrantPriorityPolicy(name) {
+	case "", FeeGrantPriorityFee:
+		return FeeGrantPriorityFee, nil
+	case FeeGrantPriorityLowest:
+		return FeeGrantPriorityLowest, nil
+	default:
+		return "", fmt.Errorf("unknown fee grant priority policy %q, expected %q or %q", name, FeeGrantPriorityFee, FeeGrantPriorityLowest)
+	}
+}
+
 const (
-	// priorityScalingFactor is a scaling factor to convert the gas price to a priority.
-	priorityScalingFactor = 1_000_000
+	// priorityScalingFactor is a scaling factor to convert the gas price to a
+	// priority. This is used for app version 1 and if the minfee param is not set.
+	priorityScalingFactor = minfee.DefaultPriorityScalingFactor
 )
 
 // ValidateTxFee implements default fee validation logic for transactions.
 // It ensures that the provided transaction fee meets a minimum threshold for the node
 // as well as a global minimum threshold and computes the tx priority based on the gas price.
-func ValidateTxFee(ctx sdk.Context, tx sdk.Tx, paramKeeper params.Keeper) (sdk.Coins, int64, error) {
+// If the maximum gas price is positive, transactions with a higher gas price are rejected
+// from the node's mempool. Fees are paid in the staking bond denom. The priority of
+// fee grant transactions is computed with the default FeeGrantPriorityFee policy.
+func ValidateTxFee(ctx sdk.Context, tx sdk.Tx, paramKeeper params.Keeper, maxGasPrice sdk.DecCoin) (sdk.Coins, int64, error) {
+	return validateTxFee(ctx, tx, getMinFeeSubspace(paramKeeper), getStakingSubspace(paramKeeper), maxGasPrice, FeeGrantPriorityFee)
+}
+
+// getMinFeeSubspace returns the minfee subspace or nil if it is not registered.
+// The subspace reads params from the store of the context it is given so it can
+// be resolved once and reused across blocks without missing governance updates.
+func getMinFeeSubspace(paramKeeper params.Keeper) *paramtypes.Subspace {
+	subspace, exists := paramKeeper.GetSubspace(minfee.ModuleName)
+	if !exists {
+		return nil
+	}
+	return &subspace
+}
+
+// getStakingSubspace returns the staking subspace or nil if it is not
+// registered. Like the minfee subspace it is resolved once and read per block.
+func getStakingSubspace(paramKeeper params.Keeper) *paramtypes.Subspace {
+	subspace, exists := paramKeeper.GetSubspace(stakingtypes.ModuleName)
+	if !exists {
+		return nil
+	}
+	return &subspace
+}
+
+// getFeeDenom returns the staking bond denom in which fees are paid. It
+// defaults to appconsts.BondDenom if the staking params are not available so
+// that networks with a different bond denom don't need to recompile appconsts.
+func getFeeDenom(ctx sdk.Context, stakingSubspace *paramtypes.Subspace) string {
+	denom := appconsts.BondDenom
+	if stakingSubspace != nil && stakingSubspace.HasKeyTable() {
+		stakingSubspace.GetIfExists(ctx, stakingtypes.KeyBondDenom, &denom)
+	}
+	return denom
+}
+
+func validateTxFee(
+	ctx sdk.Context,
+	tx sdk.Tx,
+	subspace, stakingSubspace *paramtypes.Subspace,
+	maxGasPrice sdk.DecCoin,
+	feeGrantPriority FeeGrantPriorityPolicy,
+) (sdk.Coins, int64, error) {
 	feeTx, ok := tx.(sdk.FeeTx)
 	if !ok {
 		return nil, 0, errors.Wrap(sdkerror.ErrTxDecode, "Tx must be a FeeTx")
 	}
 
-	fee := feeTx.GetFee().AmountOf(appconsts.BondDenom)
-	gas := feeTx.GetGas()
+	globalMinGasPrice := sdk.ZeroDec()
+	minFeePerBlobByte := sdk.ZeroDec()
+	scalingFactor := int64(priorityScalingFactor)
+
+	// Global minimum fee only applies to app versions greater than one
+	if ctx.BlockHeader().Version.App > v1.Version {
+		if subspace == nil {
+			return nil, 0, errors.Wrap(sdkerror.ErrInvalidRequest, "minfee is not a registered subspace")
+		}
+
+		// Gets the global minimums from the param store. PayForBlobs
+		// transactions that target a namespace with its own minimum gas
+		// price use it in place of the global minimum. The global minimum gas
+		// price is unset in the first block at the new app version if the
+		// upgrade's migrations haven't initialized it yet. Rejecting every
+		// transaction would halt the chain at the upgrade height so the
+		// default is used until it is set. These are shared with the minfee
+		// query so that it reports the fee required here.
+		globalMinGasPrice, minFeePerBlobByte = minfee.GlobalMinimums(ctx, *subspace, pfbNamespaces(feeTx))
+
+		// The priority scaling factor is not set on networks that upgraded
+		// before it was introduced in which case the default is used
+		factor := uint64(priorityScalingFactor)
+		subspace.GetIfExists(ctx, minfee.KeyPriorityScalingFactor, &factor)
+		scalingFactor = int64(factor)
+	}
+
+	feeDenom := getFeeDenom(ctx, stakingSubspace)
+
+	// The maximum gas price is node specific so it is only checked in CheckTx.
+	// Fees are only paid in the fee denom so a maximum in any other denom is a
+	// misconfiguration rather than a maximum that doesn't apply.
+	maxGasPriceAmount := sdk.ZeroDec()
+	if ctx.IsCheckTx() && isPositive(maxGasPrice.Amount) {
+		if maxGasPrice.Denom != feeDenom {
+			return nil, 0, errors.Wrapf(sdkerror.ErrInvalidCoins, "%s %s is not in the fee denom %s", FlagMaxGasPrice, maxGasPrice, feeDenom)
+		}
+		maxGasPriceAmount = maxGasPrice.Amount
+	}
+
+	fee, priority, err := ComputeFeeAndPriority(feeTx.GetFee(), feeTx.GetGas(), pfbBlobBytes(feeTx), FeeParams{
+		FeeDenom:          feeDenom,
+		NodeMinGasPrice:   ctx.MinGasPrices().AmountOf(feeDenom),
+		GlobalMinGasPrice: globalMinGasPrice,
+		MaxGasPrice:       maxGasPriceAmount,
+		MinFeePerBlobByte: minFeePerBlobByte,
+		ScalingFactor:     scalingFactor,
+		IsCheckTx:         ctx.IsCheckTx(),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if feeGrantPriority == FeeGrantPriorityLowest && feeTx.FeeGranter() != nil {
+		priority = 0
+	}
+	return fee, priority, nil
+}
+
+// pfbNamespaces returns the namespaces targeted by the PayForBlobs messages of
+// the transaction.
+func pfbNamespaces(tx sdk.Tx) [][]byte {
+	var namespaces [][]byte
+	for _, msg := range tx.GetMsgs() {
+		if pfb, ok := msg.(*blobtypes.MsgPayForBlobs); ok {
+			namespaces = append(namespaces, pfb.Namespaces...)
+		}
+	}
+	return namespaces
+}
+
+// pfbBlobBytes returns the total size in bytes of the blobs paid for by the
+// PayForBlobs messages of the transaction.
+func pfbBlobBytes(tx sdk.Tx) uint64 {
+	var blobBytes uint64
+	for _, msg := range tx.GetMsgs() {
+		if pfb, ok := msg.(*blobtypes.MsgPayForBlobs); ok {
+			for _, size := range pfb.BlobSizes {
+				blobBytes += uint64(size)
+			}
+		}
+	}
+	return blobBytes
+}
+
+// FeeParams are the minimums, maximum and priority scaling factor that the fee
+// of a transaction is validated against by ComputeFeeAndPriority. A zero or nil
+// gas price disables its check.
+type FeeParams struct {
+	// FeeDenom is the denom in which fees are paid, usually
+	// appconsts.BondDenom. Only the part of the fee paid in it is counted.
+	FeeDenom string
+	// NodeMinGasPrice is the node's minimum gas price. It is only checked in
+	// CheckTx.
+	NodeMinGasPrice sdk.Dec
+	// GlobalMinGasPrice is the network's minimum gas price.
+	GlobalMinGasPrice sdk.Dec
+	// MaxGasPrice is the node's maximum gas price. It is only checked in
+	// CheckTx.
+	MaxGasPrice sdk.Dec
+	// MinFeePerBlobByte is the network's minimum fee per byte of blob data.
+	MinFeePerBlobByte sdk.Dec
+	// ScalingFactor scales the gas price of the transaction to its priority.
+	ScalingFactor int64
+	// IsCheckTx is true if the transaction is checked for the node's mempool.
+	IsCheckTx bool
+}
+
+// ComputeFeeAndPriority validates the fee of a transaction against the node's and the
+// global minimum gas price and computes its priority. It doesn't depend on any keeper
+// or context so that it can be reused by client tooling. The global minimum fee is the
+// larger of the fee required by the global minimum gas price and by the minimum fee per
+// blob byte for the blobBytes bytes of blob data the transaction pays for, so that
+// transactions with little gas but a lot of data can't underpay.
+func ComputeFeeAndPriority(fee sdk.Coins, gas, blobBytes uint64, params FeeParams) (sdk.Coins, int64, error) {
+	bondDenomFee := fee.AmountOf(params.FeeDenom)
 
 	// Ensure that the provided fee meets a minimum threshold for the node.
 	// This is only for local mempool purposes, and thus
 	// is only ran on check tx.
-	if ctx.IsCheckTx() {
-		minGasPrice := ctx.MinGasPrices().AmountOf(appconsts.BondDenom)
-		if !minGasPrice.IsZero() {
-			err := verifyMinFee(fee, gas, minGasPrice, "insufficient minimum gas price for this node")
+	if params.IsCheckTx {
+		if isPositive(params.NodeMinGasPrice) {
+			err := verifyMinFee(bondDenomFee, minfee.RequiredFee(params.NodeMinGasPrice, gas), minfee.ErrInsufficientNodeMinGasPrice)
 			if err != nil {
 				return nil, 0, err
 			}
 		}
-	}
 
-	// Ensure that the provided fee meets a global minimum threshold.
-	// Global minimum fee only applies to app versions greater than one
-	if ctx.BlockHeader().Version.App > v1.Version {
-		subspace, exists := paramKeeper.GetSubspace(minfee.ModuleName)
-		if !exists {
-			return nil, 0, errors.Wrap(sdkerror.ErrInvalidRequest, "minfee is not a registered subspace")
-		}
-
-		if !subspace.Has(ctx, minfee.KeyGlobalMinGasPrice) {
-			return nil, 0, errors.Wrap(sdkerror.ErrKeyNotFound, "GlobalMinGasPrice")
+		// Reject fees that are likely to be a mistake. As with the minimum
+		// this is node specific and therefore doesn't affect consensus.
+		if isPositive(params.MaxGasPrice) {
+			err := verifyMaxGasPrice(bondDenomFee, gas, params.MaxGasPrice)
+			if err != nil {
+				return nil, 0, err
+			}
 		}
+	}
 
-		var globalMinGasPrice sdk.Dec
-		// Gets the global minimum gas price from the param store
-		// panics if not configured properly
-		subspace.Get(ctx, minfee.KeyGlobalMinGasPrice, &globalMinGasPrice)
-
-		err := verifyMinFee(fee, gas, globalMinGasPrice, "insufficient gas price for the network")
+	// Ensure that the provided fee meets a global minimum threshold.
+	if isPositive(params.GlobalMinGasPrice) || isPositive(params.MinFeePerBlobByte) {
+		// The global minimum is also checked in DeliverTx where the code of
+		// the error is part of the block results so it must stay the sdk's
+		// ErrInsufficientFee.
+		minFee := minfee.RequiredMinFee(params.GlobalMinGasPrice, params.MinFeePerBlobByte, gas, blobBytes)
+		err := verifyMinFee(bondDenomFee, minFee, sdkerror.ErrInsufficientFee)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, errors.Wrap(err, "insufficient gas price for the network")
 		}
 	}
 
-	priority := getTxPriority(feeTx.GetFee(), int64(gas))
-	return feeTx.GetFee(), priority, nil
+	priority := getTxPriority(fee, params.FeeDenom, int64(gas), params.ScalingFactor)
+	return fee, priority, nil
 }
 
-// verifyMinFee validates that the provided transaction fee is sufficient given the provided minimum gas price.
-func verifyMinFee(fee math.Int, gas uint64, minGasPrice sdk.Dec, errMsg string) error {
-	// Determine the required fee by multiplying required minimum gas
-	// price by the gas limit, where fee = minGasPrice * gas.
-	minFee := minGasPrice.MulInt(sdk.NewIntFromUint64(gas)).Ceil()
-	if fee.LT(minFee.TruncateInt()) {
-		return errors.Wrapf(sdkerror.ErrInsufficientFee, "%s; got: %s required at least: %s", errMsg, fee, minFee)
+// isPositive returns true if the gas price is set and greater than zero.
+func isPositive(gasPrice sdk.Dec) bool {
+	return !gasPrice.IsNil() && gasPrice.IsPositive()
+}
+
+// verifyMinFee validates that the provided transaction fee is at least the required minimum fee.
+// The provided error distinguishes which minimum was not met so that clients can react accordingly.
+func verifyMinFee(fee, minFee math.Int, minFeeErr *errors.Error) error {
+	if fee.LT(minFee) {
+		return errors.Wrapf(minFeeErr, "got: %s required at least: %s", fee, minFee)
 	}
 	return nil
 }
 
-// getTxPriority returns a naive tx priority based on the amount of the smallest denomination of the gas price
-// provided in a transaction.
-// NOTE: This implementation should not be used for txs with multiple coins.
-func getTxPriority(fee sdk.Coins, gas int64) int64 {
-	var priority int64
-	for _, c := range fee {
-		p := c.Amount.Mul(sdk.NewInt(priorityScalingFactor)).QuoRaw(gas)
-		if !p.IsInt64() {
-			continue
-		}
-		// take the lowest priority as the tx priority
-		if priority == 0 || p.Int64() < priority {
-			priority = p.Int64()
-		}
+// verifyMaxGasPrice validates that the gas price of the transaction, fee / gas, doesn't
+// exceed the provided maximum gas price.
+func verifyMaxGasPrice(fee math.Int, gas uint64, maxGasPrice sdk.Dec) error {
+	// compare fee against maxGasPrice * gas to avoid dividing by zero gas
+	maxFee := maxGasPrice.MulInt(sdk.NewIntFromUint64(gas))
+	if sdk.NewDecFromInt(fee).GT(maxFee) {
+		return errors.Wrapf(minfee.ErrGasPriceTooHigh, "got fee: %s for gas: %d; max gas price: %s", fee, gas, maxGasPrice)
 	}
+	return nil
+}
 
-	return priority
+// getTxPriority returns the tx priority based on the gas price of the fee denom
+// provided in a transaction, multiplied by the scaling factor. Fees in any other denomination are ignored as only
+// the fee denom is accepted for fees. A transaction with zero gas has zero
+// priority and a priority that would overflow is capped at the maximum int64.
+func getTxPriority(fee sdk.Coins, feeDenom string, gas int64, scalingFactor int64) int64 {
+	if gas <= 0 {
+		return 0
+	}
+	p := fee.AmountOf(feeDenom).Mul(sdk.NewInt(scalingFactor)).QuoRaw(gas)
+	if !p.IsInt64() {
+		return gomath.MaxInt64
+	}
+	return p.Int64()
 }
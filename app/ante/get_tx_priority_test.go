@@ -1,6 +1,7 @@
 package ante
 
 import (
+	"math"
 	"testing"
 
 	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
@@ -45,11 +46,39 @@ func TestGetTxPriority(t *testing.T) {
 			gas:         1_000_000,
 			expectedPri: 1000,
 		},
+		{
+			name: "multi denom fee uses the bond denom",
+			fee: sdk.NewCoins(
+				sdk.NewInt64Coin("aaa", 1),
+				sdk.NewInt64Coin(appconsts.BondDenom, 2),
+				sdk.NewInt64Coin("zzz", 1_000_000),
+			),
+			gas:         1,
+			expectedPri: 2000000,
+		},
+		{
+			name:        "multi denom fee without the bond denom",
+			fee:         sdk.NewCoins(sdk.NewInt64Coin("aaa", 1), sdk.NewInt64Coin("zzz", 1)),
+			gas:         1,
+			expectedPri: 0,
+		},
+		{
+			name:        "zero gas",
+			fee:         sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1)),
+			gas:         0,
+			expectedPri: 0,
+		},
+		{
+			name:        "priority overflows",
+			fee:         sdk.NewCoins(sdk.NewCoin(appconsts.BondDenom, sdk.NewIntFromUint64(math.MaxUint64))),
+			gas:         1,
+			expectedPri: math.MaxInt64,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			pri := getTxPriority(tc.fee, tc.gas)
+			pri := getTxPriority(tc.fee, appconsts.BondDenom, tc.gas, priorityScalingFactor)
 			assert.Equal(t, tc.expectedPri, pri)
 		})
 	}
@@ -1,6 +1,7 @@
 package ante_test
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"testing"
@@ -10,20 +11,16 @@ import (
 	"github.com/celestiaorg/celestia-app/v2/app/encoding"
 	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
 	v2 "github.com/celestiaorg/celestia-app/v2/pkg/appconsts/v2"
+	testutil "github.com/celestiaorg/celestia-app/v2/test/util"
 	"github.com/celestiaorg/celestia-app/v2/test/util/testnode"
+	blobtypes "github.com/celestiaorg/celestia-app/v2/x/blob/types"
 	"github.com/celestiaorg/celestia-app/v2/x/minfee"
-	"github.com/cosmos/cosmos-sdk/codec"
-	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
-	"github.com/cosmos/cosmos-sdk/store"
-	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	ns "github.com/celestiaorg/go-square/namespace"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
-	paramkeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
-	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
-	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
-	version "github.com/tendermint/tendermint/proto/tendermint/version"
-	tmdb "github.com/tendermint/tm-db"
 )
 
 func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
@@ -45,7 +42,8 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 
 	feeAmount := int64(1000)
 
-	paramsKeeper, stateStore := setUp(t)
+	globalMinGasPriceDec, err := sdk.NewDecFromStr(fmt.Sprintf("%f", v2.GlobalMinGasPrice))
+	require.NoError(t, err)
 
 	testCases := []struct {
 		name       string
@@ -53,7 +51,7 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 		gasLimit   uint64
 		appVersion uint64
 		isCheckTx  bool
-		expErr     bool
+		expErr     error
 	}{
 		{
 			name:       "bad tx; fee below required minimum",
@@ -61,7 +59,7 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   uint64(float64(feeAmount) / v2.GlobalMinGasPrice),
 			appVersion: uint64(2),
 			isCheckTx:  false,
-			expErr:     true,
+			expErr:     sdkerrors.ErrInsufficientFee,
 		},
 		{
 			name:       "good tx; fee equal to required minimum",
@@ -69,7 +67,6 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   uint64(float64(feeAmount) / v2.GlobalMinGasPrice),
 			appVersion: uint64(2),
 			isCheckTx:  false,
-			expErr:     false,
 		},
 		{
 			name:       "good tx; fee above required minimum",
@@ -77,7 +74,6 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   uint64(float64(feeAmount) / v2.GlobalMinGasPrice),
 			appVersion: uint64(2),
 			isCheckTx:  false,
-			expErr:     false,
 		},
 		{
 			name:       "good tx; with no fee (v1)",
@@ -85,7 +81,6 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   uint64(float64(feeAmount) / v2.GlobalMinGasPrice),
 			appVersion: uint64(1),
 			isCheckTx:  false,
-			expErr:     false,
 		},
 		{
 			name:       "good tx; gas limit and fee are maximum values",
@@ -93,7 +88,6 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   math.MaxUint64,
 			appVersion: uint64(2),
 			isCheckTx:  false,
-			expErr:     false,
 		},
 		{
 			name:       "bad tx; gas limit and fee are 0",
@@ -101,7 +95,6 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   0,
 			appVersion: uint64(2),
 			isCheckTx:  false,
-			expErr:     false,
 		},
 		{
 			name:       "good tx; minFee = 0.8, rounds up to 1",
@@ -109,7 +102,6 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   400,
 			appVersion: uint64(2),
 			isCheckTx:  false,
-			expErr:     false,
 		},
 		{
 			name:       "good tx; fee above node's required minimum",
@@ -117,7 +109,6 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   uint64(float64(feeAmount) / validatorMinGasPrice),
 			appVersion: uint64(1),
 			isCheckTx:  true,
-			expErr:     false,
 		},
 		{
 			name:       "bad tx; fee below node's required minimum",
@@ -125,7 +116,7 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			gasLimit:   uint64(float64(feeAmount) / validatorMinGasPrice),
 			appVersion: uint64(1),
 			isCheckTx:  true,
-			expErr:     true,
+			expErr:     minfee.ErrInsufficientNodeMinGasPrice,
 		},
 	}
 
@@ -135,24 +126,700 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 			builder.SetFeeAmount(tc.fee)
 			tx := builder.GetTx()
 
-			ctx := sdk.NewContext(stateStore, tmproto.Header{
-				Version: version.Consensus{
-					App: tc.appVersion,
-				},
-			}, tc.isCheckTx, nil)
-
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, tc.appVersion, tc.isCheckTx, minfee.Params{GlobalMinGasPrice: globalMinGasPriceDec})
 			ctx = ctx.WithMinGasPrices(sdk.DecCoins{validatorMinGasPriceCoin})
 
-			globalminGasPriceDec, err := sdk.NewDecFromStr(fmt.Sprintf("%f", v2.GlobalMinGasPrice))
+			_, _, err := ante.ValidateTxFee(ctx, tx, paramsKeeper, sdk.DecCoin{})
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestValidateTxFeeZeroGas verifies that a transaction declaring zero gas is
+// assigned a priority of zero rather than causing a division by zero.
+func TestValidateTxFeeZeroGas(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	builder := encCfg.TxConfig.NewTxBuilder()
+	err := builder.SetMsgs(banktypes.NewMsgSend(
+		testnode.RandomAddress().(sdk.AccAddress),
+		testnode.RandomAddress().(sdk.AccAddress),
+		sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))),
+	)
+	require.NoError(t, err)
+	fee := sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1000))
+	builder.SetGasLimit(0)
+	builder.SetFeeAmount(fee)
+	tx := builder.GetTx()
+
+	for _, appVersion := range []uint64{1, 2} {
+		for _, isCheckTx := range []bool{true, false} {
+			t.Run(fmt.Sprintf("app version %d check tx %t", appVersion, isCheckTx), func(t *testing.T) {
+				paramsKeeper, ctx := testutil.SetupMinFeeParams(t, appVersion, isCheckTx, minfee.Params{GlobalMinGasPrice: sdk.NewDecWithPrec(2, 3)})
+				ctx = ctx.WithMinGasPrices(sdk.NewDecCoins(sdk.NewDecCoinFromDec(appconsts.BondDenom, sdk.NewDecWithPrec(2, 3))))
+
+				gotFee, priority, err := ante.ValidateTxFee(ctx, tx, paramsKeeper, sdk.DecCoin{})
+				require.NoError(t, err)
+				require.Equal(t, fee, gotFee)
+				require.Zero(t, priority)
+			})
+		}
+	}
+}
+
+// TestValidateTxFeeNamespaceMinGasPrice verifies that PayForBlobs transactions
+// targeting a namespace with its own minimum gas price are checked against it
+// while all other transactions are checked against the global minimum.
+func TestValidateTxFeeNamespaceMinGasPrice(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	overridden := ns.MustNewV0(bytes.Repeat([]byte{1}, ns.NamespaceVersionZeroIDSize))
+	other := ns.MustNewV0(bytes.Repeat([]byte{2}, ns.NamespaceVersionZeroIDSize))
+	signer := testnode.RandomAddress().(sdk.AccAddress)
+
+	// the global minimum requires a fee of 100 and the override a fee of 1000
+	gasLimit := uint64(100_000)
+	globalMinGasPrice := sdk.NewDecWithPrec(1, 3)
+	overrides := []minfee.NamespaceMinGasPrice{{Namespace: overridden.Bytes(), MinGasPrice: sdk.NewDecWithPrec(1, 2)}}
+
+	pfb := func(namespaces ...ns.Namespace) sdk.Msg {
+		msg := &blobtypes.MsgPayForBlobs{Signer: signer.String()}
+		for _, namespace := range namespaces {
+			msg.Namespaces = append(msg.Namespaces, namespace.Bytes())
+		}
+		return msg
+	}
+	send := banktypes.NewMsgSend(signer, testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	testCases := []struct {
+		name   string
+		msg    sdk.Msg
+		fee    int64
+		expErr bool
+	}{
+		{
+			name: "send uses the global minimum",
+			msg:  send,
+			fee:  100,
+		},
+		{
+			name: "pfb to another namespace uses the global minimum",
+			msg:  pfb(other),
+			fee:  100,
+		},
+		{
+			name:   "pfb to an overridden namespace below its minimum",
+			msg:    pfb(overridden),
+			fee:    999,
+			expErr: true,
+		},
+		{
+			name: "pfb to an overridden namespace meeting its minimum",
+			msg:  pfb(overridden),
+			fee:  1000,
+		},
+		{
+			name:   "pfb to several namespaces uses the highest minimum",
+			msg:    pfb(other, overridden),
+			fee:    100,
+			expErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := encCfg.TxConfig.NewTxBuilder()
+			require.NoError(t, builder.SetMsgs(tc.msg))
+			builder.SetGasLimit(gasLimit)
+			builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, tc.fee)))
+
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, false, minfee.Params{
+				GlobalMinGasPrice:     globalMinGasPrice,
+				NamespaceMinGasPrices: overrides,
+			})
+
+			_, _, err := ante.ValidateTxFee(ctx, builder.GetTx(), paramsKeeper, sdk.DecCoin{})
+			if tc.expErr {
+				require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestValidateTxFeeMinFeePerBlobByte verifies that PayForBlobs transactions
+// with a low gas limit but a large amount of blob data must pay at least the
+// min fee per blob byte for their data.
+func TestValidateTxFeeMinFeePerBlobByte(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	signer := testnode.RandomAddress().(sdk.AccAddress)
+
+	// the global minimum requires a fee of 100 for the gas limit
+	gasLimit := uint64(100_000)
+	globalMinGasPrice := sdk.NewDecWithPrec(1, 3)
+	minFeePerBlobByte := sdk.NewDecWithPrec(1, 2)
+
+	pfb := func(blobSizes ...uint32) sdk.Msg {
+		return &blobtypes.MsgPayForBlobs{Signer: signer.String(), BlobSizes: blobSizes}
+	}
+	send := banktypes.NewMsgSend(signer, testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	testCases := []struct {
+		name              string
+		msg               sdk.Msg
+		minFeePerBlobByte sdk.Dec
+		fee               int64
+		expErr            bool
+	}{
+		{
+			name:              "send only pays the global minimum",
+			msg:               send,
+			minFeePerBlobByte: minFeePerBlobByte,
+			fee:               100,
+		},
+		{
+			name:              "small blob only pays the global minimum",
+			msg:               pfb(1_000),
+			minFeePerBlobByte: minFeePerBlobByte,
+			fee:               100,
+		},
+		{
+			name:              "large blob below the min fee per blob byte",
+			msg:               pfb(1_000_000),
+			minFeePerBlobByte: minFeePerBlobByte,
+			fee:               9_999,
+			expErr:            true,
+		},
+		{
+			name:              "large blob meeting the min fee per blob byte",
+			msg:               pfb(1_000_000),
+			minFeePerBlobByte: minFeePerBlobByte,
+			fee:               10_000,
+		},
+		{
+			name:              "the sizes of all blobs are counted",
+			msg:               pfb(500_000, 500_000),
+			minFeePerBlobByte: minFeePerBlobByte,
+			fee:               9_999,
+			expErr:            true,
+		},
+		{
+			name: "large blob only pays the global minimum if unset",
+			msg:  pfb(1_000_000),
+			fee:  100,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := encCfg.TxConfig.NewTxBuilder()
+			require.NoError(t, builder.SetMsgs(tc.msg))
+			builder.SetGasLimit(gasLimit)
+			builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, tc.fee)))
+
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, false, minfee.Params{
+				GlobalMinGasPrice: globalMinGasPrice,
+				MinFeePerBlobByte: tc.minFeePerBlobByte,
+			})
+
+			_, _, err := ante.ValidateTxFee(ctx, builder.GetTx(), paramsKeeper, sdk.DecCoin{})
+			if tc.expErr {
+				require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestValidateTxFeeMatchesMinFeeQuery verifies that the fee returned by the
+// minfee query for a transaction is exactly the minimum the ante handler
+// accepts.
+func TestValidateTxFeeMatchesMinFeeQuery(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	overridden := ns.MustNewV0(bytes.Repeat([]byte{1}, ns.NamespaceVersionZeroIDSize))
+	other := ns.MustNewV0(bytes.Repeat([]byte{2}, ns.NamespaceVersionZeroIDSize))
+	signer := testnode.RandomAddress().(sdk.AccAddress)
+
+	gasLimit := uint64(100_001)
+	globalMinGasPrice := sdk.NewDecWithPrec(1, 3)
+	minFeePerBlobByte := sdk.NewDecWithPrec(1, 2)
+	overrides := []minfee.NamespaceMinGasPrice{{Namespace: overridden.Bytes(), MinGasPrice: sdk.NewDecWithPrec(25, 3)}}
+
+	pfb := func(namespace ns.Namespace, blobSize uint32) *blobtypes.MsgPayForBlobs {
+		return &blobtypes.MsgPayForBlobs{Signer: signer.String(), Namespaces: [][]byte{namespace.Bytes()}, BlobSizes: []uint32{blobSize}}
+	}
+	send := banktypes.NewMsgSend(signer, testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10)))
+
+	testCases := []struct {
+		name string
+		msg  sdk.Msg
+	}{
+		{name: "send", msg: send},
+		{name: "pfb paying the global minimum", msg: pfb(other, 1_000)},
+		{name: "pfb paying the min fee per blob byte", msg: pfb(other, 1_000_000)},
+		{name: "pfb paying the namespace minimum", msg: pfb(overridden, 1_000)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, false, minfee.Params{
+				GlobalMinGasPrice:     globalMinGasPrice,
+				NamespaceMinGasPrices: overrides,
+				MinFeePerBlobByte:     minFeePerBlobByte,
+			})
+
+			req := &minfee.QueryMinFeeRequest{GasLimit: gasLimit}
+			if msg, ok := tc.msg.(*blobtypes.MsgPayForBlobs); ok {
+				req.Namespaces = msg.Namespaces
+				req.BlobBytes = uint64(msg.BlobSizes[0])
+			}
+			resp, err := minfee.NewQueryServerImpl(paramsKeeper).MinFee(sdk.WrapSDKContext(ctx), req)
 			require.NoError(t, err)
 
-			subspace, _ := paramsKeeper.GetSubspace(minfee.ModuleName)
-			minfee.RegisterMinFeeParamTable(subspace)
-			subspace.Set(ctx, minfee.KeyGlobalMinGasPrice, globalminGasPriceDec)
+			validate := func(fee sdk.Int) error {
+				builder := encCfg.TxConfig.NewTxBuilder()
+				require.NoError(t, builder.SetMsgs(tc.msg))
+				builder.SetGasLimit(gasLimit)
+				builder.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(appconsts.BondDenom, fee)))
+				_, _, err := ante.ValidateTxFee(ctx, builder.GetTx(), paramsKeeper, sdk.DecCoin{})
+				return err
+			}
+			require.NoError(t, validate(resp.MinFee))
+			require.ErrorIs(t, validate(resp.MinFee.SubRaw(1)), sdkerrors.ErrInsufficientFee)
+		})
+	}
+}
+
+func TestMinFeeRounding(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	builder := encCfg.TxConfig.NewTxBuilder()
+	err := builder.SetMsgs(banktypes.NewMsgSend(
+		testnode.RandomAddress().(sdk.AccAddress),
+		testnode.RandomAddress().(sdk.AccAddress),
+		sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))),
+	)
+	require.NoError(t, err)
+
+	// 0.0025utia is a gas price for which most gas limits require a fractional fee
+	minGasPrice, err := sdk.NewDecFromStr("0.0025")
+	require.NoError(t, err)
 
-			_, _, err = ante.ValidateTxFee(ctx, tx, paramsKeeper)
+	testCases := []struct {
+		name     string
+		fee      int64
+		gasLimit uint64
+		expErr   bool
+	}{
+		{
+			name:     "good tx; required fee is whole (250)",
+			fee:      250,
+			gasLimit: 100_000,
+		},
+		{
+			name:     "bad tx; fee below whole required fee (250)",
+			fee:      249,
+			gasLimit: 100_000,
+			expErr:   true,
+		},
+		{
+			name:     "good tx; fractional required fee (250.0025) rounds up",
+			fee:      251,
+			gasLimit: 100_001,
+		},
+		{
+			name:     "bad tx; fee below fractional required fee (250.0025)",
+			fee:      250,
+			gasLimit: 100_001,
+			expErr:   true,
+		},
+		{
+			name:     "bad tx; fee below fractional required fee (250.9975)",
+			fee:      250,
+			gasLimit: 100_399,
+			expErr:   true,
+		},
+		{
+			name:     "good tx; fractional required fee below one unit (0.0025) rounds up",
+			fee:      1,
+			gasLimit: 1,
+		},
+		{
+			name:     "bad tx; zero fee for fractional required fee (0.0025)",
+			fee:      0,
+			gasLimit: 1,
+			expErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder.SetGasLimit(tc.gasLimit)
+			builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, tc.fee)))
+			tx := builder.GetTx()
+
+			// the node's minimum is only checked in CheckTx for which app
+			// version 1 skips the global minimum
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 1, true, minfee.Params{})
+			ctx = ctx.WithMinGasPrices(sdk.DecCoins{sdk.NewDecCoinFromDec(appconsts.BondDenom, minGasPrice)})
+
+			_, _, err := ante.ValidateTxFee(ctx, tx, paramsKeeper, sdk.DecCoin{})
 			if tc.expErr {
-				require.Error(t, err)
+				require.ErrorIs(t, err, minfee.ErrInsufficientNodeMinGasPrice)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestComputeFeeAndPriority(t *testing.T) {
+	fee := sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1_000))
+	gas := uint64(100_000)
+
+	testCases := []struct {
+		name              string
+		nodeMinGasPrice   sdk.Dec
+		globalMinGasPrice sdk.Dec
+		maxGasPrice       sdk.Dec
+		minFeePerBlobByte sdk.Dec
+		blobBytes         uint64
+		isCheckTx         bool
+		feeDenom          string
+		expPriority       int64
+		expErr            error
+	}{
+		{
+			name:        "no minimum or maximum",
+			isCheckTx:   true,
+			expPriority: 10_000,
+		},
+		{
+			name:              "fee meets the global minimum",
+			globalMinGasPrice: sdk.NewDecWithPrec(1, 2),
+			expPriority:       10_000,
+		},
+		{
+			name:              "fee below the global minimum",
+			globalMinGasPrice: sdk.NewDecWithPrec(2, 2),
+			expErr:            sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:            "fee below the node's minimum in CheckTx",
+			nodeMinGasPrice: sdk.NewDecWithPrec(2, 2),
+			isCheckTx:       true,
+			expErr:          minfee.ErrInsufficientNodeMinGasPrice,
+		},
+		{
+			name:            "node's minimum is ignored in DeliverTx",
+			nodeMinGasPrice: sdk.NewDecWithPrec(2, 2),
+			expPriority:     10_000,
+		},
+		{
+			name:        "fee above the maximum in CheckTx",
+			maxGasPrice: sdk.NewDecWithPrec(1, 3),
+			isCheckTx:   true,
+			expErr:      minfee.ErrGasPriceTooHigh,
+		},
+		{
+			name:              "fee in another denom doesn't count towards the minimum",
+			globalMinGasPrice: sdk.NewDecWithPrec(1, 2),
+			feeDenom:          "ustake",
+			expErr:            sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:              "fee meets the min fee per blob byte",
+			minFeePerBlobByte: sdk.NewDecWithPrec(1, 1),
+			blobBytes:         10_000,
+			expPriority:       10_000,
+		},
+		{
+			name:              "fee below the min fee per blob byte",
+			minFeePerBlobByte: sdk.NewDecWithPrec(1, 1),
+			blobBytes:         10_001,
+			expErr:            sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:              "fee meets the global minimum but not the min fee per blob byte",
+			globalMinGasPrice: sdk.NewDecWithPrec(1, 2),
+			minFeePerBlobByte: sdk.NewDecWithPrec(1, 1),
+			blobBytes:         20_000,
+			expErr:            sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:              "fee meets the min fee per blob byte but not the global minimum",
+			globalMinGasPrice: sdk.NewDecWithPrec(2, 2),
+			minFeePerBlobByte: sdk.NewDecWithPrec(1, 1),
+			blobBytes:         100,
+			expErr:            sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:        "fee in another denom has zero priority",
+			isCheckTx:   true,
+			feeDenom:    "ustake",
+			expPriority: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			feeDenom := appconsts.BondDenom
+			if tc.feeDenom != "" {
+				feeDenom = tc.feeDenom
+			}
+			gotFee, priority, err := ante.ComputeFeeAndPriority(fee, gas, tc.blobBytes, ante.FeeParams{
+				FeeDenom:          feeDenom,
+				NodeMinGasPrice:   tc.nodeMinGasPrice,
+				GlobalMinGasPrice: tc.globalMinGasPrice,
+				MaxGasPrice:       tc.maxGasPrice,
+				MinFeePerBlobByte: tc.minFeePerBlobByte,
+				ScalingFactor:     minfee.DefaultPriorityScalingFactor,
+				IsCheckTx:         tc.isCheckTx,
+			})
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, fee, gotFee)
+			require.Equal(t, tc.expPriority, priority)
+		})
+	}
+}
+
+func TestPriorityScalingFactor(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	builder := encCfg.TxConfig.NewTxBuilder()
+	err := builder.SetMsgs(banktypes.NewMsgSend(
+		testnode.RandomAddress().(sdk.AccAddress),
+		testnode.RandomAddress().(sdk.AccAddress),
+		sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))),
+	)
+	require.NoError(t, err)
+	builder.SetGasLimit(100_000)
+	builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1_000)))
+	tx := builder.GetTx()
+
+	globalMinGasPriceDec, err := sdk.NewDecFromStr(fmt.Sprintf("%f", v2.GlobalMinGasPrice))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		appVersion    uint64
+		scalingFactor uint64
+		expPriority   int64
+	}{
+		{
+			name:        "v1 uses the default scaling factor",
+			appVersion:  1,
+			expPriority: 10_000,
+		},
+		{
+			name:          "v1 ignores the param",
+			appVersion:    1,
+			scalingFactor: 1_000,
+			expPriority:   10_000,
+		},
+		{
+			name:        "v2 falls back to the default if the param is not set",
+			appVersion:  2,
+			expPriority: 10_000,
+		},
+		{
+			name:          "v2 reads the param",
+			appVersion:    2,
+			scalingFactor: 1_000,
+			expPriority:   10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, tc.appVersion, false, minfee.Params{
+				GlobalMinGasPrice:     globalMinGasPriceDec,
+				PriorityScalingFactor: tc.scalingFactor,
+			})
+
+			_, priority, err := ante.ValidateTxFee(ctx, tx, paramsKeeper, sdk.DecCoin{})
+			require.NoError(t, err)
+			require.Equal(t, tc.expPriority, priority)
+		})
+	}
+}
+
+// TestValidateTxFeeStakingBondDenom verifies that fees are validated and
+// prioritized in the staking bond denom when it differs from the default.
+func TestValidateTxFeeStakingBondDenom(t *testing.T) {
+	const bondDenom = "ustake"
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	builder := encCfg.TxConfig.NewTxBuilder()
+	err := builder.SetMsgs(banktypes.NewMsgSend(
+		testnode.RandomAddress().(sdk.AccAddress),
+		testnode.RandomAddress().(sdk.AccAddress),
+		sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 10))),
+	)
+	require.NoError(t, err)
+	gasLimit := uint64(100_000)
+	builder.SetGasLimit(gasLimit)
+
+	globalMinGasPriceDec, err := sdk.NewDecFromStr(fmt.Sprintf("%f", v2.GlobalMinGasPrice))
+	require.NoError(t, err)
+	// the node requires a fee of at least 0.01 * 100_000 = 1_000
+	nodeMinGasPrice := sdk.NewDecCoinFromDec(bondDenom, sdk.NewDecWithPrec(1, 2))
+
+	testCases := []struct {
+		name        string
+		fee         sdk.Coins
+		maxGasPrice sdk.DecCoin
+		isCheckTx   bool
+		expPriority int64
+		expErr      error
+	}{
+		{
+			name:        "fee in the bond denom meets the global minimum",
+			fee:         sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1_000)),
+			expPriority: 10_000,
+		},
+		{
+			name:   "fee in the default denom is ignored",
+			fee:    sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1_000)),
+			expErr: sdkerrors.ErrInsufficientFee,
+		},
+		{
+			name:        "fee in the bond denom meets the node's minimum",
+			fee:         sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1_000)),
+			isCheckTx:   true,
+			expPriority: 10_000,
+		},
+		{
+			name:      "fee in the bond denom below the node's minimum",
+			fee:       sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 999)),
+			isCheckTx: true,
+			expErr:    minfee.ErrInsufficientNodeMinGasPrice,
+		},
+		{
+			name:        "fee in the bond denom above the node's maximum",
+			fee:         sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1_000)),
+			maxGasPrice: sdk.NewDecCoinFromDec(bondDenom, sdk.NewDecWithPrec(1, 3)),
+			isCheckTx:   true,
+			expErr:      minfee.ErrGasPriceTooHigh,
+		},
+		{
+			name:        "node's maximum in the default denom",
+			fee:         sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1_000)),
+			maxGasPrice: sdk.NewDecCoinFromDec(appconsts.BondDenom, sdk.NewDec(1)),
+			isCheckTx:   true,
+			expErr:      sdkerrors.ErrInvalidCoins,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, tc.isCheckTx, minfee.Params{GlobalMinGasPrice: globalMinGasPriceDec})
+			ctx = ctx.WithMinGasPrices(sdk.DecCoins{nodeMinGasPrice})
+
+			stakingSubspace, _ := paramsKeeper.GetSubspace(stakingtypes.ModuleName)
+			stakingSubspace = stakingSubspace.WithKeyTable(stakingtypes.ParamKeyTable())
+			stakingSubspace.Set(ctx, stakingtypes.KeyBondDenom, bondDenom)
+
+			builder.SetFeeAmount(tc.fee)
+			gotFee, priority, err := ante.ValidateTxFee(ctx, builder.GetTx(), paramsKeeper, tc.maxGasPrice)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.fee, gotFee)
+			require.Equal(t, tc.expPriority, priority)
+		})
+	}
+}
+
+func TestMaxGasPrice(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	builder := encCfg.TxConfig.NewTxBuilder()
+	err := builder.SetMsgs(banktypes.NewMsgSend(
+		testnode.RandomAddress().(sdk.AccAddress),
+		testnode.RandomAddress().(sdk.AccAddress),
+		sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))),
+	)
+	require.NoError(t, err)
+	gasLimit := uint64(100_000)
+	builder.SetGasLimit(gasLimit)
+
+	globalMinGasPriceDec, err := sdk.NewDecFromStr(fmt.Sprintf("%f", v2.GlobalMinGasPrice))
+	require.NoError(t, err)
+	maxGasPrice := sdk.NewDecCoinFromDec(appconsts.BondDenom, sdk.NewDec(1))
+	maxFee := int64(gasLimit) // maxGasPrice * gasLimit
+
+	testCases := []struct {
+		name        string
+		fee         int64
+		maxGasPrice sdk.DecCoin
+		isCheckTx   bool
+		expErr      error
+	}{
+		{
+			name:        "good tx; fee equal to maximum",
+			fee:         maxFee,
+			maxGasPrice: maxGasPrice,
+			isCheckTx:   true,
+		},
+		{
+			name:        "bad tx; fee above maximum",
+			fee:         maxFee + 1,
+			maxGasPrice: maxGasPrice,
+			isCheckTx:   true,
+			expErr:      minfee.ErrGasPriceTooHigh,
+		},
+		{
+			name:        "good tx; maximum is not checked in DeliverTx",
+			fee:         maxFee + 1,
+			maxGasPrice: maxGasPrice,
+			isCheckTx:   false,
+		},
+		{
+			name:        "good tx; zero maximum is disabled",
+			fee:         maxFee + 1,
+			maxGasPrice: sdk.NewDecCoinFromDec(appconsts.BondDenom, sdk.ZeroDec()),
+			isCheckTx:   true,
+		},
+		{
+			name:      "good tx; unset maximum is disabled",
+			fee:       maxFee + 1,
+			isCheckTx: true,
+		},
+		{
+			name:        "bad config; maximum in another denom than the fee denom",
+			fee:         maxFee,
+			maxGasPrice: sdk.NewDecCoinFromDec("ustake", sdk.NewDec(1)),
+			isCheckTx:   true,
+			expErr:      sdkerrors.ErrInvalidCoins,
+		},
+		{
+			name:        "good tx; maximum in another denom is not checked in DeliverTx",
+			fee:         maxFee,
+			maxGasPrice: sdk.NewDecCoinFromDec("ustake", sdk.NewDec(1)),
+			isCheckTx:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, tc.isCheckTx, minfee.Params{GlobalMinGasPrice: globalMinGasPriceDec})
+
+			builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, tc.fee)))
+			_, _, err := ante.ValidateTxFee(ctx, builder.GetTx(), paramsKeeper, tc.maxGasPrice)
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
 			} else {
 				require.NoError(t, err)
 			}
@@ -160,21 +827,193 @@ func TestCheckTxFeeWithGlobalMinGasPrices(t *testing.T) {
 	}
 }
 
-func setUp(t *testing.T) (paramkeeper.Keeper, storetypes.CommitMultiStore) {
-	storeKey := sdk.NewKVStoreKey(paramtypes.StoreKey)
-	tStoreKey := storetypes.NewTransientStoreKey(paramtypes.TStoreKey)
+func TestValidateTxFeeWrapperReflectsParamChanges(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	builder := encCfg.TxConfig.NewTxBuilder()
+	err := builder.SetMsgs(banktypes.NewMsgSend(
+		testnode.RandomAddress().(sdk.AccAddress),
+		testnode.RandomAddress().(sdk.AccAddress),
+		sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))),
+	)
+	require.NoError(t, err)
+	builder.SetGasLimit(100_000)
+	builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1_000)))
+	tx := builder.GetTx()
+
+	paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, false, minfee.Params{})
+	// the subspace is resolved once before any params are set as is the case
+	// when the ante handler is constructed in app.New
+	feeChecker := ante.ValidateTxFeeWrapper(paramsKeeper, sdk.DecCoin{}, ante.FeeGrantPriorityFee)
+	subspace, _ := paramsKeeper.GetSubspace(minfee.ModuleName)
+
+	// the global min gas price is not yet set so the default is used
+	_, _, err = feeChecker(ctx, tx)
+	require.NoError(t, err)
+
+	subspace.Set(ctx, minfee.KeyGlobalMinGasPrice, sdk.NewDecWithPrec(1, 2))
+	_, _, err = feeChecker(ctx, tx)
+	require.NoError(t, err)
+
+	// a governance proposal raises the global min gas price in the next block
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	subspace.Set(ctx, minfee.KeyGlobalMinGasPrice, sdk.NewDecWithPrec(1, 1))
+	_, _, err = feeChecker(ctx, tx)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+}
+
+// TestValidateTxFeeUpgradeBoundary verifies that the default global minimum
+// gas price is applied in the first block at app version 2 if the minfee
+// params have not been initialized yet, rather than rejecting every
+// transaction and halting the chain at the upgrade height.
+func TestValidateTxFeeUpgradeBoundary(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	gasLimit := uint64(100_000)
+	defaultFee := minfee.RequiredFee(minfee.DefaultGlobalMinGasPrice, gasLimit).Int64()
+
+	testCases := []struct {
+		name   string
+		fee    int64
+		expErr error
+	}{
+		{
+			name: "fee meets the default with the params unset",
+			fee:  defaultFee,
+		},
+		{
+			name:   "fee below the default with the params unset",
+			fee:    defaultFee - 1,
+			expErr: sdkerrors.ErrInsufficientFee,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := encCfg.TxConfig.NewTxBuilder()
+			err := builder.SetMsgs(banktypes.NewMsgSend(
+				testnode.RandomAddress().(sdk.AccAddress),
+				testnode.RandomAddress().(sdk.AccAddress),
+				sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))),
+			)
+			require.NoError(t, err)
+			builder.SetGasLimit(gasLimit)
+			builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, tc.fee)))
+			tx := builder.GetTx()
+
+			// the first block after the upgrade from app version 1
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, false, minfee.Params{})
+			ctx = ctx.WithBlockHeight(100)
+
+			_, _, err = ante.ValidateTxFee(ctx, tx, paramsKeeper, sdk.DecCoin{})
+			if tc.expErr != nil {
+				require.ErrorIs(t, err, tc.expErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFeeGrantPriority(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	newTx := func(feeGranter sdk.AccAddress) sdk.FeeTx {
+		builder := encCfg.TxConfig.NewTxBuilder()
+		err := builder.SetMsgs(banktypes.NewMsgSend(
+			testnode.RandomAddress().(sdk.AccAddress),
+			testnode.RandomAddress().(sdk.AccAddress),
+			sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))),
+		)
+		require.NoError(t, err)
+		builder.SetGasLimit(100_000)
+		builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1_000)))
+		builder.SetFeeGranter(feeGranter)
+		return builder.GetTx()
+	}
+	tx := newTx(nil)
+	feeGrantTx := newTx(testnode.RandomAddress().(sdk.AccAddress))
+
+	testCases := []struct {
+		name                string
+		policy              ante.FeeGrantPriorityPolicy
+		expPriority         int64
+		expFeeGrantPriority int64
+	}{
+		{
+			name:                "fee policy computes the priority from the gas price",
+			policy:              ante.FeeGrantPriorityFee,
+			expPriority:         10_000,
+			expFeeGrantPriority: 10_000,
+		},
+		{
+			name:                "lowest policy only lowers fee grant transactions",
+			policy:              ante.FeeGrantPriorityLowest,
+			expPriority:         10_000,
+			expFeeGrantPriority: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, true, minfee.Params{GlobalMinGasPrice: sdk.NewDecWithPrec(1, 2)})
+
+			feeChecker := ante.ValidateTxFeeWrapper(paramsKeeper, sdk.DecCoin{}, tc.policy)
+			_, priority, err := feeChecker(ctx, tx)
+			require.NoError(t, err)
+			require.Equal(t, tc.expPriority, priority)
+
+			fee, priority, err := feeChecker(ctx, feeGrantTx)
+			require.NoError(t, err)
+			require.Equal(t, feeGrantTx.GetFee(), fee)
+			require.Equal(t, tc.expFeeGrantPriority, priority)
+		})
+	}
+}
+
+func TestParseFeeGrantPriorityPolicy(t *testing.T) {
+	policy, err := ante.ParseFeeGrantPriorityPolicy("")
+	require.NoError(t, err)
+	require.Equal(t, ante.FeeGrantPriorityFee, policy)
+
+	policy, err = ante.ParseFeeGrantPriorityPolicy("lowest")
+	require.NoError(t, err)
+	require.Equal(t, ante.FeeGrantPriorityLowest, policy)
+
+	_, err = ante.ParseFeeGrantPriorityPolicy("highest")
+	require.Error(t, err)
+}
+
+func BenchmarkValidateTxFee(b *testing.B) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	builder := encCfg.TxConfig.NewTxBuilder()
+	err := builder.SetMsgs(banktypes.NewMsgSend(
+		testnode.RandomAddress().(sdk.AccAddress),
+		testnode.RandomAddress().(sdk.AccAddress),
+		sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 10))),
+	)
+	require.NoError(b, err)
+	builder.SetGasLimit(100_000)
+	builder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1_000)))
+	tx := builder.GetTx()
 
-	// Create the state store
-	db := tmdb.NewMemDB()
-	stateStore := store.NewCommitMultiStore(db)
-	stateStore.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, db)
-	stateStore.MountStoreWithDB(tStoreKey, storetypes.StoreTypeTransient, nil)
-	require.NoError(t, stateStore.LoadLatestVersion())
+	paramsKeeper, ctx := testutil.SetupMinFeeParams(b, 2, false, minfee.Params{GlobalMinGasPrice: sdk.NewDecWithPrec(1, 2)})
 
-	registry := codectypes.NewInterfaceRegistry()
+	b.Run("per tx subspace lookup", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _, err := ante.ValidateTxFee(ctx, tx, paramsKeeper, sdk.DecCoin{})
+			require.NoError(b, err)
+		}
+	})
 
-	// Create a params keeper and set the global min gas price
-	paramsKeeper := paramkeeper.NewKeeper(codec.NewProtoCodec(registry), codec.NewLegacyAmino(), storeKey, tStoreKey)
-	paramsKeeper.Subspace(minfee.ModuleName)
-	return paramsKeeper, stateStore
+	b.Run("cached subspace", func(b *testing.B) {
+		feeChecker := ante.ValidateTxFeeWrapper(paramsKeeper, sdk.DecCoin{}, ante.FeeGrantPriorityFee)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _, err := feeChecker(ctx, tx)
+			require.NoError(b, err)
+		}
+	})
 }
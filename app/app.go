@@ -1,6 +1,7 @@
 package app
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/celestiaorg/celestia-app/v2/app/module"
@@ -428,10 +429,17 @@ func New(
 	// NOTE: we may consider parsing `appOpts` inside module constructors. For the moment
 	// we prefer to be more strict in what arguments the modules expect.
 	skipGenesisInvariants := cast.ToBool(appOpts.Get(crisis.FlagSkipGenesisInvariants))
+	maxGasPrice, err := parseMaxGasPrice(cast.ToString(appOpts.Get(ante.FlagMaxGasPrice)))
+	if err != nil {
+		panic(err)
+	}
+	feeGrantPriority, err := ante.ParseFeeGrantPriorityPolicy(cast.ToString(appOpts.Get(ante.FlagFeeGrantPriority)))
+	if err != nil {
+		panic(err)
+	}
 
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
-	var err error
 	app.mm, err = module.NewManager([]module.VersionedModule{
 		{
 			Module:      genutil.NewAppModule(app.AccountKeeper, app.StakingKeeper, app.BaseApp.DeliverTx, encodingConfig.TxConfig),
@@ -650,6 +658,8 @@ func New(
 		ante.DefaultSigVerificationGasConsumer,
 		app.IBCKeeper,
 		app.ParamsKeeper,
+		maxGasPrice,
+		feeGrantPriority,
 		app.MsgGateKeeper,
 	))
 	app.SetPostHandler(posthandler.New())
@@ -883,7 +893,7 @@ func initParamsKeeper(appCodec codec.BinaryCodec, legacyAmino *codec.LegacyAmino
 	paramsKeeper.Subspace(icahosttypes.SubModuleName)
 	paramsKeeper.Subspace(blobtypes.ModuleName)
 	paramsKeeper.Subspace(blobstreamtypes.ModuleName)
-	paramsKeeper.Subspace(minfee.ModuleName)
+	paramsKeeper.Subspace(minfee.ModuleName).WithKeyTable(minfee.ParamKeyTable())
 	paramsKeeper.Subspace(packetforwardtypes.ModuleName)
 
 	return paramsKeeper
@@ -904,3 +914,18 @@ func extractRegisters(m sdkmodule.BasicManager, soloRegisters ...encoding.Module
 	}
 	return s
 }
+
+// parseMaxGasPrice parses the maximum gas price from the node config, i.e.
+// "100utia". It must be a single coin as fees are only paid in the staking bond
+// denom, which the ante handler checks its denom against. An empty string
+// disables the maximum.
+func parseMaxGasPrice(maxGasPrice string) (sdk.DecCoin, error) {
+	if maxGasPrice == "" {
+		return sdk.DecCoin{}, nil
+	}
+	coin, err := sdk.ParseDecCoin(maxGasPrice)
+	if err != nil {
+		return sdk.DecCoin{}, fmt.Errorf("invalid %s, must be a single coin such as 100utia: %w", ante.FlagMaxGasPrice, err)
+	}
+	return coin, nil
+}
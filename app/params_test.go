@@ -0,0 +1,134 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/app/ante"
+	"github.com/celestiaorg/celestia-app/v2/app/encoding"
+	v2 "github.com/celestiaorg/celestia-app/v2/pkg/appconsts/v2"
+	blobtypes "github.com/celestiaorg/celestia-app/v2/x/blob/types"
+	"github.com/celestiaorg/celestia-app/v2/x/minfee"
+	ns "github.com/celestiaorg/go-square/namespace"
+	"github.com/cosmos/cosmos-sdk/store"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	version "github.com/tendermint/tendermint/proto/tendermint/version"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+// TestMinFeeParamsAfterRestart verifies that the minfee params stored by a
+// previous run of the node are used after a restart, when the subspaces are
+// constructed afresh and InitGenesis doesn't run again.
+func TestMinFeeParamsAfterRestart(t *testing.T) {
+	encCfg := encoding.MakeConfig(ModuleEncodingRegisters...)
+	storeKey := sdk.NewKVStoreKey(paramstypes.StoreKey)
+	tStoreKey := storetypes.NewTransientStoreKey(paramstypes.TStoreKey)
+
+	db := tmdb.NewMemDB()
+	stateStore := store.NewCommitMultiStore(db)
+	stateStore.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(tStoreKey, storetypes.StoreTypeTransient, nil)
+	require.NoError(t, stateStore.LoadLatestVersion())
+	ctx := sdk.NewContext(stateStore, tmproto.Header{
+		Version: version.Consensus{
+			App: v2.Version,
+		},
+	}, false, nil)
+
+	// the params are stored by a previous run of the node
+	globalMinGasPrice := sdk.NewDecWithPrec(1, 2)
+	subspace, _ := initParamsKeeper(encCfg.Codec, encCfg.Amino, storeKey, tStoreKey).GetSubspace(minfee.ModuleName)
+	subspace.Set(ctx, minfee.KeyGlobalMinGasPrice, globalMinGasPrice)
+	subspace.Set(ctx, minfee.KeyPriorityScalingFactor, uint64(1_000))
+	minFeePerBlobByte := sdk.NewDecWithPrec(1, 1)
+	subspace.Set(ctx, minfee.KeyMinFeePerBlobByte, minFeePerBlobByte)
+	namespace := ns.MustNewV0(bytes.Repeat([]byte{1}, ns.NamespaceVersionZeroIDSize)).Bytes()
+	namespaceMinGasPrice := sdk.NewDecWithPrec(1, 1)
+	subspace.Set(ctx, minfee.KeyNamespaceMinGasPrices, []minfee.NamespaceMinGasPrice{{Namespace: namespace, MinGasPrice: namespaceMinGasPrice}})
+
+	restarted := initParamsKeeper(encCfg.Codec, encCfg.Amino, storeKey, tStoreKey)
+
+	gasLimit := uint64(100_000)
+	minFee := minfee.RequiredFee(globalMinGasPrice, gasLimit)
+	signer := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+	send := banktypes.NewMsgSend(signer, sdk.AccAddress(bytes.Repeat([]byte{2}, 20)), sdk.NewCoins(sdk.NewInt64Coin(BondDenom, 10)))
+	validate := func(fee sdk.Int, msgs ...sdk.Msg) (int64, error) {
+		if len(msgs) == 0 {
+			msgs = []sdk.Msg{send}
+		}
+		builder := encCfg.TxConfig.NewTxBuilder()
+		require.NoError(t, builder.SetMsgs(msgs...))
+		builder.SetGasLimit(gasLimit)
+		builder.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(BondDenom, fee)))
+		_, priority, err := ante.ValidateTxFee(ctx, builder.GetTx(), restarted, sdk.DecCoin{})
+		return priority, err
+	}
+
+	priority, err := validate(minFee)
+	require.NoError(t, err)
+	// a gas price of 0.01 scaled by the stored factor rather than the default
+	require.Equal(t, int64(10), priority)
+
+	_, err = validate(minFee.SubRaw(1))
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// a PayForBlobs transaction whose blob data costs more than its gas
+	blobBytes := uint64(100_000)
+	minBlobFee := minfee.RequiredBlobFee(minFeePerBlobByte, blobBytes)
+	require.True(t, minBlobFee.GT(minFee))
+	pfb := &blobtypes.MsgPayForBlobs{Signer: signer.String(), BlobSizes: []uint32{uint32(blobBytes)}}
+	_, err = validate(minBlobFee, pfb)
+	require.NoError(t, err)
+	_, err = validate(minBlobFee.SubRaw(1), pfb)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// a PayForBlobs transaction that targets an overridden namespace
+	minNamespaceFee := minfee.RequiredFee(namespaceMinGasPrice, gasLimit)
+	pfb = &blobtypes.MsgPayForBlobs{Signer: signer.String(), Namespaces: [][]byte{namespace}, BlobSizes: []uint32{1}}
+	_, err = validate(minNamespaceFee, pfb)
+	require.NoError(t, err)
+	_, err = validate(minNamespaceFee.SubRaw(1), pfb)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFee)
+
+	// the minfee query reports the stored params rather than the defaults
+	resp, err := minfee.NewQueryServerImpl(restarted).MinFee(sdk.WrapSDKContext(ctx), &minfee.QueryMinFeeRequest{GasLimit: gasLimit})
+	require.NoError(t, err)
+	require.Equal(t, globalMinGasPrice, resp.GlobalMinGasPrice)
+	require.Equal(t, minFee, resp.MinFee)
+
+	resp, err = minfee.NewQueryServerImpl(restarted).MinFee(sdk.WrapSDKContext(ctx), &minfee.QueryMinFeeRequest{
+		GasLimit:   gasLimit,
+		Namespaces: [][]byte{namespace},
+		BlobBytes:  2 * blobBytes,
+	})
+	require.NoError(t, err)
+	require.Equal(t, namespaceMinGasPrice, resp.GlobalMinGasPrice)
+	require.Equal(t, minfee.RequiredBlobFee(minFeePerBlobByte, 2*blobBytes), resp.MinFee)
+}
+
+func TestParseMaxGasPrice(t *testing.T) {
+	maxGasPrice, err := parseMaxGasPrice("")
+	require.NoError(t, err)
+	require.True(t, maxGasPrice.Amount.IsNil())
+
+	maxGasPrice, err = parseMaxGasPrice("100utia")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoin("utia", sdk.NewInt(100)), maxGasPrice)
+
+	// the denom is checked against the staking bond denom by the ante handler
+	maxGasPrice, err = parseMaxGasPrice("0.5ustake")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoinFromDec("ustake", sdk.NewDecWithPrec(5, 1)), maxGasPrice)
+
+	_, err = parseMaxGasPrice("100utia,1ustake")
+	require.Error(t, err)
+
+	_, err = parseMaxGasPrice("100")
+	require.Error(t, err)
+}
@@ -45,6 +45,10 @@ func (app *App) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePr
 		ante.DefaultSigVerificationGasConsumer,
 		app.IBCKeeper,
 		app.ParamsKeeper,
+		// the maximum gas price and the fee grant priority are node specific
+		// and only apply to CheckTx
+		sdk.DecCoin{},
+		ante.FeeGrantPriorityFee,
 		app.MsgGateKeeper,
 	)
 	txs := FilterTxs(app.Logger(), sdkCtx, handler, app.txConfig, req.BlockData.Txs)
@@ -46,6 +46,10 @@ func (app *App) ProcessProposal(req abci.RequestProcessProposal) (resp abci.Resp
 		ante.DefaultSigVerificationGasConsumer,
 		app.IBCKeeper,
 		app.ParamsKeeper,
+		// the maximum gas price and the fee grant priority are node specific
+		// and only apply to CheckTx
+		sdk.DecCoin{},
+		ante.FeeGrantPriorityFee,
 		app.MsgGateKeeper,
 	)
 	sdkCtx := app.NewProposalContext(req.Header)
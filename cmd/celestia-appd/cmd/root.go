@@ -8,6 +8,7 @@ import (
 	bscmd "github.com/celestiaorg/celestia-app/v2/x/blobstream/client"
 
 	"github.com/celestiaorg/celestia-app/v2/app"
+	"github.com/celestiaorg/celestia-app/v2/app/ante"
 	"github.com/celestiaorg/celestia-app/v2/app/encoding"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/simapp/simd/cmd"
@@ -160,6 +161,8 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig encoding.Config) {
 func addModuleInitFlags(startCmd *cobra.Command) {
 	crisis.AddModuleInitFlags(startCmd)
 	startCmd.Flags().Int64(UpgradeHeightFlag, 0, "Upgrade height to switch from v1 to v2. Must be coordinated amongst all validators")
+	startCmd.Flags().String(ante.FlagMaxGasPrice, "", "Maximum gas price, i.e. 100utia, of transactions accepted into the mempool. Leaving empty disables the maximum")
+	startCmd.Flags().String(ante.FlagFeeGrantPriority, string(ante.FeeGrantPriorityFee), "Mempool priority of transactions paid by a fee granter: \"fee\" computes it from the gas price, \"lowest\" assigns zero")
 }
 
 func queryCommand() *cobra.Command {
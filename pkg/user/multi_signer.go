@@ -0,0 +1,289 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/grpc"
+)
+
+// MultiSigner builds, signs and broadcasts transactions whose messages are
+// signed by several accounts, i.e. a MsgSend from each of them. Every account
+// is represented by its own Signer which tracks its sequence number
+// independently: a transaction uses, and increments, the local sequence number
+// of each of its signers. The first signer of a transaction pays the fee and
+// its connection and broadcast mode are used.
+type MultiSigner struct {
+	signers []*Signer
+}
+
+// NewMultiSigner returns a MultiSigner for transactions signed by any of the
+// provided signers.
+func NewMultiSigner(signers ...*Signer) *MultiSigner {
+	return &MultiSigner{signers: signers}
+}
+
+// CreateTx forms a transaction from the provided messages and signs it with
+// the signer of each message, incrementing their local sequence numbers.
+func (m *MultiSigner) CreateTx(msgs []sdktypes.Msg, opts ...TxOption) (authsigning.Tx, error) {
+	signers, err := m.signersOf(msgs)
+	if err != nil {
+		return nil, err
+	}
+	unlock := lockSigners(signers)
+	defer unlock()
+
+	return signMultiSignerTx(signers, reserveSequences(signers), msgs, opts...)
+}
+
+// SubmitTx forms a transaction from the provided messages, signs it with the
+// signer of each message and submits it to the chain.
+func (m *MultiSigner) SubmitTx(ctx context.Context, msgs []sdktypes.Msg, opts ...TxOption) (*sdktypes.TxResponse, error) {
+	resp, err := m.BroadcastTx(ctx, msgs, opts...)
+	if err != nil {
+		return resp, err
+	}
+
+	return m.ConfirmTx(ctx, resp.TxHash)
+}
+
+// BroadcastTx forms a transaction from the provided messages, signs it with
+// the signer of each message and broadcasts it without waiting for it to be
+// committed. If the transaction is rejected, the local sequence numbers of its
+// signers are reset so that they can be reused. Unlike Signer.BroadcastTx, a
+// sequence mismatch isn't resolved as the error doesn't tell which of the
+// signers is out of sync.
+func (m *MultiSigner) BroadcastTx(ctx context.Context, msgs []sdktypes.Msg, opts ...TxOption) (*sdktypes.TxResponse, error) {
+	signers, err := m.signersOf(msgs)
+	if err != nil {
+		return nil, err
+	}
+	// the connection is read before locking as getConn takes the read lock
+	conn := signers[0].getConn()
+	unlock := lockSigners(signers)
+	defer unlock()
+
+	sequences := reserveSequences(signers)
+	resp, err := broadcastMultiSignerTx(ctx, conn, signers, sequences, msgs, opts...)
+	if err != nil {
+		// the transaction didn't enter the mempool so the sequence numbers
+		// weren't used
+		for i, s := range signers {
+			s.localSequence = sequences[i]
+		}
+		return resp, err
+	}
+
+	for i, s := range signers {
+		s.outboundSequences[sequences[i]] = struct{}{}
+		s.reverseTxHashSequenceMap[resp.TxHash] = sequences[i]
+	}
+	return resp, nil
+}
+
+// ConfirmTx waits for the transaction to be committed like Signer.ConfirmTx and
+// updates the last known sequence number of each of its signers. The
+// transaction stops being tracked by its signers once ConfirmTx returns, also
+// when its outcome is unknown, e.g. because the context was cancelled.
+func (m *MultiSigner) ConfirmTx(ctx context.Context, txHash string) (*sdktypes.TxResponse, error) {
+	signers := m.signersOfTx(txHash)
+	if len(signers) == 0 {
+		if len(m.signers) == 0 {
+			return nil, errors.New("no signers")
+		}
+		return m.signers[0].ConfirmTx(ctx, txHash)
+	}
+
+	resp, err := signers[0].ConfirmTx(ctx, txHash)
+	// the last known sequence numbers only advance if the transaction was
+	// committed successfully. Otherwise the transaction is dropped by all of
+	// its signers, including the first one if it wasn't found.
+	for _, s := range signers {
+		s.updateNetworkSequence(txHash, err == nil)
+	}
+	return resp, err
+}
+
+// EstimateGas simulates a transaction formed from the provided messages and
+// signed by the signer of each message and returns the gas it used.
+func (m *MultiSigner) EstimateGas(ctx context.Context, msgs []sdktypes.Msg, opts ...TxOption) (uint64, error) {
+	signers, err := m.signersOf(msgs)
+	if err != nil {
+		return 0, err
+	}
+	conn := signers[0].getConn()
+	unlock := lockSigners(signers)
+	sequences := make([]uint64, len(signers))
+	for i, s := range signers {
+		sequences[i] = s.localSequence
+	}
+	tx, err := signMultiSignerTx(signers, sequences, msgs, opts...)
+	unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	txBytes, err := signers[0].EncodeTx(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := sdktx.NewServiceClient(conn).Simulate(ctx, &sdktx.SimulateRequest{
+		TxBytes: txBytes,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.GasInfo.GasUsed, nil
+}
+
+// signersOf returns the signers of the messages in the order in which they
+// sign the transaction.
+func (m *MultiSigner) signersOf(msgs []sdktypes.Msg) ([]*Signer, error) {
+	var signers []*Signer
+	seen := make(map[string]bool)
+	for _, msg := range msgs {
+		for _, address := range msg.GetSigners() {
+			if seen[address.String()] {
+				continue
+			}
+			seen[address.String()] = true
+
+			signer := m.signer(address)
+			if signer == nil {
+				return nil, fmt.Errorf("no signer for %s", address.String())
+			}
+			signers = append(signers, signer)
+		}
+	}
+	if len(signers) == 0 {
+		return nil, errors.New("transaction has no signers")
+	}
+	return signers, nil
+}
+
+// signersOfTx returns the signers of a broadcast transaction that is yet to be
+// confirmed.
+func (m *MultiSigner) signersOfTx(txHash string) []*Signer {
+	var signers []*Signer
+	for _, s := range m.signers {
+		s.mtx.RLock()
+		_, exists := s.reverseTxHashSequenceMap[txHash]
+		s.mtx.RUnlock()
+		if exists {
+			signers = append(signers, s)
+		}
+	}
+	return signers
+}
+
+func (m *MultiSigner) signer(address sdktypes.AccAddress) *Signer {
+	for _, s := range m.signers {
+		if s.address.Equals(address) {
+			return s
+		}
+	}
+	return nil
+}
+
+// lockSigners locks the signers in the order of their addresses so that
+// transactions with overlapping signers can't deadlock. It returns the function
+// that unlocks them.
+func lockSigners(signers []*Signer) func() {
+	sorted := make([]*Signer, len(signers))
+	copy(sorted, signers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].address, sorted[j].address) < 0
+	})
+	for _, s := range sorted {
+		s.mtx.Lock()
+	}
+	return func() {
+		for _, s := range sorted {
+			s.mtx.Unlock()
+		}
+	}
+}
+
+// reserveSequences returns the local sequence number of each signer and
+// increments it.
+// CONTRACT: assumes the caller holds the lock of each signer
+func reserveSequences(signers []*Signer) []uint64 {
+	sequences := make([]uint64, len(signers))
+	for i, s := range signers {
+		sequences[i] = s.getAndIncrementSequence()
+	}
+	return sequences
+}
+
+// signMultiSignerTx forms a transaction from the provided messages and signs it
+// with each of the signers using the provided sequence numbers.
+// CONTRACT: assumes the caller holds the lock of each signer
+func signMultiSignerTx(signers []*Signer, sequences []uint64, msgs []sdktypes.Msg, opts ...TxOption) (authsigning.Tx, error) {
+	builder := signers[0].txBuilder(opts...)
+	if err := builder.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+
+	// Every signer signs over the signer infos of all of them so the draft
+	// signatures of all signers must be set before any signature is produced
+	drafts := make([]signing.SignatureV2, len(signers))
+	for i, s := range signers {
+		drafts[i] = s.getSignatureV2(sequences[i], nil)
+	}
+	if err := builder.SetSignatures(drafts...); err != nil {
+		return nil, fmt.Errorf("error setting draft signatures: %w", err)
+	}
+
+	signatures := make([]signing.SignatureV2, len(signers))
+	for i, s := range signers {
+		signature, err := s.createSignature(builder, sequences[i])
+		if err != nil {
+			return nil, fmt.Errorf("error creating signature of %s: %w", s.address.String(), err)
+		}
+		signatures[i] = s.getSignatureV2(sequences[i], signature)
+	}
+	if err := builder.SetSignatures(signatures...); err != nil {
+		return nil, fmt.Errorf("error setting signatures: %w", err)
+	}
+
+	return builder.GetTx(), nil
+}
+
+// broadcastMultiSignerTx signs the transaction and broadcasts it over the
+// provided connection with the first signer's broadcast mode.
+// CONTRACT: assumes the caller holds the lock of each signer
+func broadcastMultiSignerTx(ctx context.Context, conn *grpc.ClientConn, signers []*Signer, sequences []uint64, msgs []sdktypes.Msg, opts ...TxOption) (*sdktypes.TxResponse, error) {
+	tx, err := signMultiSignerTx(signers, sequences, msgs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := signers[0].EncodeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := sdktx.NewServiceClient(conn).BroadcastTx(
+		ctx,
+		&sdktx.BroadcastTxRequest{
+			Mode:    signers[0].broadcastMode,
+			TxBytes: txBytes,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if resp.TxResponse.Code != abci.CodeTypeOK {
+		return resp.TxResponse, fmt.Errorf("tx failed with code %d: %s", resp.TxResponse.Code, resp.TxResponse.RawLog)
+	}
+	return resp.TxResponse, nil
+}
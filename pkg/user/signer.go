@@ -44,6 +44,8 @@ type Signer struct {
 	mtx sync.RWMutex
 	// how often to poll the network for confirmation of a transaction
 	pollTime time.Duration
+	// the mode in which transactions are broadcast
+	broadcastMode sdktx.BroadcastMode
 	// the signers local view of the sequence number
 	localSequence uint64
 	// the chains last known sequence number
@@ -87,6 +89,7 @@ func NewSigner(
 		localSequence:            sequence,
 		networkSequence:          sequence,
 		pollTime:                 DefaultPollTime,
+		broadcastMode:            sdktx.BroadcastMode_BROADCAST_MODE_SYNC,
 		outboundSequences:        make(map[uint64]struct{}),
 		reverseTxHashSequenceMap: make(map[string]uint64),
 	}, nil
@@ -168,6 +171,12 @@ func (s *Signer) SubmitPayForBlob(ctx context.Context, blobs []*blob.Blob, opts
 	return s.ConfirmTx(ctx, resp.TxHash)
 }
 
+// BroadcastPayForBlob forms a transaction from the provided blobs, signs it, and broadcasts it to the
+// chain without waiting for it to be committed. TxOptions may be provided to set the fee and gas limit.
+func (s *Signer) BroadcastPayForBlob(ctx context.Context, blobs []*blob.Blob, opts ...TxOption) (*sdktypes.TxResponse, error) {
+	return s.broadcastPayForBlob(ctx, blobs, opts...)
+}
+
 func (s *Signer) broadcastPayForBlob(ctx context.Context, blobs []*blob.Blob, opts ...TxOption) (*sdktypes.TxResponse, error) {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
@@ -279,7 +288,7 @@ func (s *Signer) broadcastTx(ctx context.Context, txBytes []byte, sequence uint6
 	resp, err := txClient.BroadcastTx(
 		ctx,
 		&sdktx.BroadcastTxRequest{
-			Mode:    sdktx.BroadcastMode_BROADCAST_MODE_SYNC,
+			Mode:    s.broadcastMode,
 			TxBytes: txBytes,
 		},
 	)
@@ -363,7 +372,7 @@ func (s *Signer) retryBroadcastingTx(ctx context.Context, txBytes []byte, newSeq
 // hash. It will continually loop until the context is cancelled, the tx is found or an error
 // is encountered.
 func (s *Signer) ConfirmTx(ctx context.Context, txHash string) (*sdktypes.TxResponse, error) {
-	txClient := sdktx.NewServiceClient(s.grpc)
+	txClient := sdktx.NewServiceClient(s.getConn())
 
 	pollTicker := time.NewTicker(s.getPollTime())
 	defer pollTicker.Stop()
@@ -409,7 +418,7 @@ func (s *Signer) EstimateGas(ctx context.Context, msgs []sdktypes.Msg, opts ...T
 		return 0, err
 	}
 
-	resp, err := sdktx.NewServiceClient(s.grpc).Simulate(ctx, &sdktx.SimulateRequest{
+	resp, err := sdktx.NewServiceClient(s.getConn()).Simulate(ctx, &sdktx.SimulateRequest{
 		TxBytes: txBytes,
 	})
 	if err != nil {
@@ -441,12 +450,37 @@ func (s *Signer) SetPollTime(pollTime time.Duration) {
 	s.pollTime = pollTime
 }
 
+// SetBroadcastMode sets the mode in which transactions are broadcast. The
+// default, sync, waits for the transaction to pass CheckTx. Async returns
+// without any checks so transactions rejected by the mempool are only noticed
+// when they fail to be confirmed. Block waits for the transaction to be
+// committed.
+func (s *Signer) SetBroadcastMode(mode sdktx.BroadcastMode) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.broadcastMode = mode
+}
+
 func (s *Signer) getPollTime() time.Duration {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 	return s.pollTime
 }
 
+// SetConn sets the grpc connection used for broadcasting, confirming and
+// simulating transactions
+func (s *Signer) SetConn(conn *grpc.ClientConn) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.grpc = conn
+}
+
+func (s *Signer) getConn() *grpc.ClientConn {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.grpc
+}
+
 // PubKey returns the public key of the signer
 func (s *Signer) PubKey() cryptotypes.PubKey {
 	return s.pk
@@ -12,6 +12,7 @@ import (
 	"github.com/celestiaorg/celestia-app/v2/test/util/blobfactory"
 	"github.com/celestiaorg/celestia-app/v2/test/util/testnode"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -29,14 +30,15 @@ func TestSignerTestSuite(t *testing.T) {
 type SignerTestSuite struct {
 	suite.Suite
 
-	ctx    testnode.Context
-	encCfg encoding.Config
-	signer *user.Signer
+	ctx      testnode.Context
+	encCfg   encoding.Config
+	signer   *user.Signer
+	cosigner *user.Signer
 }
 
 func (s *SignerTestSuite) SetupSuite() {
 	s.encCfg = encoding.MakeConfig(app.ModuleEncodingRegisters...)
-	s.ctx, _, _ = testnode.NewNetwork(s.T(), testnode.DefaultConfig().WithFundedAccounts("a"))
+	s.ctx, _, _ = testnode.NewNetwork(s.T(), testnode.DefaultConfig().WithFundedAccounts("a", "b"))
 	_, err := s.ctx.WaitForHeight(1)
 	s.Require().NoError(err)
 	rec, err := s.ctx.Keyring.Key("a")
@@ -45,6 +47,12 @@ func (s *SignerTestSuite) SetupSuite() {
 	s.Require().NoError(err)
 	s.signer, err = user.SetupSigner(s.ctx.GoContext(), s.ctx.Keyring, s.ctx.GRPCClient, addr, s.encCfg)
 	s.Require().NoError(err)
+	rec, err = s.ctx.Keyring.Key("b")
+	s.Require().NoError(err)
+	addr, err = rec.GetAddress()
+	s.Require().NoError(err)
+	s.cosigner, err = user.SetupSigner(s.ctx.GoContext(), s.ctx.Keyring, s.ctx.GRPCClient, addr, s.encCfg)
+	s.Require().NoError(err)
 }
 
 func (s *SignerTestSuite) TestSubmitPayForBlob() {
@@ -69,6 +77,81 @@ func (s *SignerTestSuite) TestSubmitTx() {
 	require.EqualValues(t, 0, resp.Code)
 }
 
+func (s *SignerTestSuite) TestSubmitTxBroadcastModes() {
+	t := s.T()
+	fee := user.SetFee(1e6)
+	gas := user.SetGasLimit(1e6)
+	defer s.signer.SetBroadcastMode(sdktx.BroadcastMode_BROADCAST_MODE_SYNC)
+
+	for _, mode := range []sdktx.BroadcastMode{sdktx.BroadcastMode_BROADCAST_MODE_ASYNC, sdktx.BroadcastMode_BROADCAST_MODE_BLOCK} {
+		t.Run(mode.String(), func(t *testing.T) {
+			s.signer.SetBroadcastMode(mode)
+			msg := bank.NewMsgSend(s.signer.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10)))
+			ctx, cancel := context.WithTimeout(s.ctx.GoContext(), 30*time.Second)
+			defer cancel()
+			resp, err := s.signer.SubmitTx(ctx, []sdk.Msg{msg}, fee, gas)
+			require.NoError(t, err)
+			require.EqualValues(t, 0, resp.Code)
+			require.Positive(t, resp.Height)
+		})
+	}
+}
+
+func (s *SignerTestSuite) TestMultiSignerSubmitTx() {
+	t := s.T()
+	fee := user.SetFee(1e6)
+	gas := user.SetGasLimit(1e6)
+	multiSigner := user.NewMultiSigner(s.signer, s.cosigner)
+
+	// each transaction is signed by both accounts and must use, and
+	// increment, the sequence number of each of them
+	for i := 0; i < 3; i++ {
+		signerSequence, cosignerSequence := s.signer.LocalSequence(), s.cosigner.LocalSequence()
+		msgs := []sdk.Msg{
+			bank.NewMsgSend(s.signer.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10))),
+			bank.NewMsgSend(s.cosigner.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10))),
+		}
+		ctx, cancel := context.WithTimeout(s.ctx.GoContext(), 30*time.Second)
+		resp, err := multiSigner.SubmitTx(ctx, msgs, fee, gas)
+		cancel()
+		require.NoError(t, err)
+		require.EqualValues(t, abci.CodeTypeOK, resp.Code)
+		require.Equal(t, signerSequence+1, s.signer.LocalSequence())
+		require.Equal(t, cosignerSequence+1, s.cosigner.LocalSequence())
+		require.Equal(t, s.signer.LocalSequence(), s.signer.NetworkSequence())
+		require.Equal(t, s.cosigner.LocalSequence(), s.cosigner.NetworkSequence())
+	}
+
+	t.Run("gas estimation", func(t *testing.T) {
+		msgs := []sdk.Msg{
+			bank.NewMsgSend(s.signer.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10))),
+			bank.NewMsgSend(s.cosigner.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10))),
+		}
+		gas, err := multiSigner.EstimateGas(s.ctx.GoContext(), msgs)
+		require.NoError(t, err)
+		require.Greater(t, gas, uint64(0))
+	})
+
+	t.Run("rejected transactions don't use the sequence numbers", func(t *testing.T) {
+		signerSequence, cosignerSequence := s.signer.LocalSequence(), s.cosigner.LocalSequence()
+		// the fee is below the minimum
+		msgs := []sdk.Msg{
+			bank.NewMsgSend(s.signer.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10))),
+			bank.NewMsgSend(s.cosigner.Address(), testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10))),
+		}
+		_, err := multiSigner.BroadcastTx(s.ctx.GoContext(), msgs, user.SetFee(1), gas)
+		require.Error(t, err)
+		require.Equal(t, signerSequence, s.signer.LocalSequence())
+		require.Equal(t, cosignerSequence, s.cosigner.LocalSequence())
+	})
+
+	t.Run("messages of an unknown signer", func(t *testing.T) {
+		msg := bank.NewMsgSend(testnode.RandomAddress().(sdk.AccAddress), s.signer.Address(), sdk.NewCoins(sdk.NewInt64Coin(app.BondDenom, 10)))
+		_, err := multiSigner.CreateTx([]sdk.Msg{msg}, fee, gas)
+		require.Error(t, err)
+	})
+}
+
 func (s *SignerTestSuite) TestConfirmTx() {
 	t := s.T()
 
@@ -8,7 +8,6 @@ import (
 	"os"
 	"os/signal"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/celestiaorg/celestia-app/v2/app"
@@ -34,13 +33,18 @@ const (
 
 // Values for all flags
 var (
-	keyPath, masterAccName, keyMnemonic, grpcEndpoint string
-	blobSizes, blobAmounts                            string
-	seed                                              int64
-	pollTime                                          time.Duration
-	send, sendIterations, sendAmount                  int
-	stake, stakeValue, blob                           int
-	useFeegrant, suppressLogs                         bool
+	keyPath, masterAccName, keyMnemonic, grpcEndpoint, configPath, chainID string
+	accountsFile, mempoolRPC, broadcastMode                                string
+	mempoolHigh, mempoolLow, submitBatchSize, confirmationDepth            int
+	expectedSquareSize, fundingConcurrency                                 int
+	blobSizes, blobAmounts                                                 string
+	seed                                                                   int64
+	pollTime                                                               time.Duration
+	pollJitter, gasAdjustment, maxLatencyBreaches                          float64
+	setupTimeout, drainTimeout, latencyThreshold                           time.Duration
+	send, sendIterations, sendAmount                                       int
+	stake, stakeValue, blob, blobNamespaces                                int
+	useFeegrant, suppressLogs, jsonLogs, tracing, refund                   bool
 )
 
 func main() {
@@ -90,10 +94,26 @@ well funded account that can act as the master account. The command runs until a
 				return err
 			}
 
+			// load the options and sequences from the config file if provided
+			var (
+				opts      = txsim.DefaultOptions()
+				sequences = []txsim.Sequence{}
+			)
+			if configPath != "" {
+				var specs []txsim.SequenceSpec
+				opts, specs, err = txsim.LoadOptions(configPath)
+				if err != nil {
+					return err
+				}
+				for _, spec := range specs {
+					sequences = append(sequences, spec.Sequences()...)
+				}
+			}
+
 			// get the rpc and grpc endpoints
 			if grpcEndpoint == "" {
 				grpcEndpoint = os.Getenv(TxsimGRPC)
-				if grpcEndpoint == "" {
+				if grpcEndpoint == "" && configPath == "" {
 					return errors.New("grpc endpoints not specified. Use --grpc-endpoint or TXSIM_GRPC env var")
 				}
 			}
@@ -102,13 +122,11 @@ well funded account that can act as the master account. The command runs until a
 				masterAccName = os.Getenv(TxsimMasterAccName)
 			}
 
-			if stake == 0 && send == 0 && blob == 0 {
-				return errors.New("no sequences specified. Use --stake, --send or --blob")
+			if stake == 0 && send == 0 && blob == 0 && len(sequences) == 0 {
+				return errors.New("no sequences specified. Use --stake, --send, --blob or --config")
 			}
 
 			// setup the sequences
-			sequences := []txsim.Sequence{}
-
 			if stake > 0 {
 				sequences = append(sequences, txsim.NewStakeSequence(stakeValue).Clone(stake)...)
 			}
@@ -118,20 +136,20 @@ well funded account that can act as the master account. The command runs until a
 			}
 
 			if blob > 0 {
-				sizes, err := readRange(blobSizes)
+				sizes, err := txsim.ParseRange(blobSizes)
 				if err != nil {
 					return fmt.Errorf("invalid blob sizes: %w", err)
 				}
 
-				blobsPerPFB, err := readRange(blobAmounts)
+				blobsPerPFB, err := txsim.ParseRange(blobAmounts)
 				if err != nil {
 					return fmt.Errorf("invalid blob amounts: %w", err)
 				}
 
-				sequences = append(sequences, txsim.NewBlobSequence(sizes, blobsPerPFB).Clone(blob)...)
+				sequences = append(sequences, txsim.NewBlobSequence(sizes, blobsPerPFB).WithNamespacePool(blobNamespaces).Clone(blob)...)
 			}
 
-			if seed == 0 {
+			if seed == 0 && configPath == "" {
 				if os.Getenv(TxsimSeed) != "" {
 					seed, err = strconv.ParseInt(os.Getenv(TxsimSeed), 10, 64)
 					if err != nil {
@@ -150,9 +168,53 @@ well funded account that can act as the master account. The command runs until a
 				}
 			}
 
-			opts := txsim.DefaultOptions().
-				SpecifyMasterAccount(masterAccName).
-				WithSeed(seed)
+			// flags take precedence over the config file
+			if pollJitter != 0 {
+				opts.WithPollJitter(pollJitter)
+			}
+			if setupTimeout != 0 {
+				opts.WithSetupTimeout(setupTimeout)
+			}
+			if drainTimeout != 0 {
+				opts.WithDrainTimeout(drainTimeout)
+			}
+			if fundingConcurrency != 0 {
+				opts.WithFundingConcurrency(fundingConcurrency)
+			}
+			if latencyThreshold != 0 {
+				opts.WithLatencyThreshold(latencyThreshold)
+			}
+			if maxLatencyBreaches != 0 {
+				opts.WithMaxLatencyBreaches(maxLatencyBreaches)
+			}
+			if broadcastMode != "" {
+				mode, err := txsim.ParseBroadcastMode(broadcastMode)
+				if err != nil {
+					return err
+				}
+				opts.WithBroadcastMode(mode)
+			}
+			if gasAdjustment != 0 {
+				opts.WithGasAdjustment(gasAdjustment)
+			}
+			if submitBatchSize > 1 {
+				opts.WithSubmitBatchSize(submitBatchSize)
+			}
+			if confirmationDepth != 0 {
+				opts.WithConfirmationDepth(confirmationDepth)
+			}
+			if expectedSquareSize != 0 {
+				opts.WithSquareSizeCheck(expectedSquareSize)
+			}
+			if masterAccName != "" {
+				opts.SpecifyMasterAccount(masterAccName)
+			}
+			if seed != 0 {
+				opts.WithSeed(seed)
+			}
+			if chainID != "" {
+				opts.WithChainID(chainID)
+			}
 
 			if useFeegrant {
 				opts.UseFeeGrant()
@@ -162,6 +224,26 @@ well funded account that can act as the master account. The command runs until a
 				opts.SuppressLogs()
 			}
 
+			if jsonLogs {
+				opts.WithJSONLogs()
+			}
+
+			if tracing {
+				opts.WithTracing()
+			}
+
+			if refund {
+				opts.WithRefundOnExit()
+			}
+
+			if accountsFile != "" {
+				opts.WithAccountsFile(accountsFile)
+			}
+
+			if mempoolRPC != "" {
+				opts.WithMempoolBackpressure(mempoolRPC, mempoolHigh, mempoolLow)
+			}
+
 			encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
 			err = txsim.Run(
 				cmd.Context(),
@@ -188,8 +270,17 @@ func flags() *flag.FlagSet {
 	flags.StringVar(&masterAccName, "master", "", "the account name of the master account. Leaving empty will result in using the account with the most funds.")
 	flags.StringVar(&keyMnemonic, "key-mnemonic", "", "space separated mnemonic for the keyring. The hdpath used is an empty string")
 	flags.StringVar(&grpcEndpoint, "grpc-endpoint", "", "grpc endpoint to a running node")
+	flags.StringVar(&configPath, "config", "", "path to a YAML file describing the options and sequences to run. Flags take precedence over the file")
+	flags.StringVar(&chainID, "chain-id", "", "expected chain id of the network. Leaving empty will use the chain id reported by the node")
 	flags.Int64Var(&seed, "seed", 0, "seed for the random number generator")
 	flags.DurationVar(&pollTime, "poll-time", user.DefaultPollTime, "poll time for the transaction client")
+	flags.Float64Var(&pollJitter, "poll-jitter", 0, "randomize the poll time of each sequence within ± this fraction of the poll time, i.e. 0.2")
+	flags.Float64Var(&gasAdjustment, "gas-adjustment", 0, "simulate the gas of transactions without a gas limit and multiply it by this factor, i.e. 1.3 (must be at least 1)")
+	flags.DurationVar(&setupTimeout, "setup-timeout", 0, "maximum time to fund the accounts of the sequences before failing. Leaving as 0 will wait indefinitely")
+	flags.DurationVar(&drainTimeout, "drain-timeout", 0, "on shutdown, maximum time to wait for transactions in flight to be committed. Leaving as 0 abandons them")
+	flags.IntVar(&submitBatchSize, "submit-batch-size", 1, "number of transactions each sequence broadcasts before waiting for them to be committed")
+	flags.IntVar(&expectedSquareSize, "expected-square-size", 0, "warn if a block that includes a PFB doesn't have this square size. Leaving as 0 disables the check")
+	flags.IntVar(&confirmationDepth, "confirmation-depth", 0, "number of blocks, including the one with the transaction, to wait for before a transaction is complete")
 	flags.IntVar(&send, "send", 0, "number of send sequences to run")
 	flags.IntVar(&sendIterations, "send-iterations", 1000, "number of send iterations to run per sequence")
 	flags.IntVar(&sendAmount, "send-amount", 1000, "amount to send from one account to another")
@@ -198,30 +289,19 @@ func flags() *flag.FlagSet {
 	flags.IntVar(&blob, "blob", 0, "number of blob sequences to run")
 	flags.StringVar(&blobSizes, "blob-sizes", "100-1000", "range of blob sizes to send")
 	flags.StringVar(&blobAmounts, "blob-amounts", "1", "range of blobs to send per PFB in a sequence")
+	flags.IntVar(&blobNamespaces, "blob-namespaces", 0, "size of the pool of random namespaces that blobs are drawn from. Leaving as 0 will use a new namespace for every blob")
 	flags.BoolVar(&useFeegrant, "feegrant", false, "use the feegrant module to pay for fees")
 	flags.BoolVar(&suppressLogs, "suppressLogs", false, "disable logging")
+	flags.BoolVar(&jsonLogs, "json-logs", false, "write logs to stdout as JSON lines")
+	flags.BoolVar(&refund, "refund", false, "send the remaining balance of all allocated accounts back to the master account on exit")
+	flags.StringVar(&accountsFile, "accounts-file", "", "path to a file that the allocated accounts are persisted to and reused from across runs. Requires --key-path")
+	flags.StringVar(&mempoolRPC, "mempool-rpc", "", "rpc endpoint queried for the mempool size. If set, submissions pause while the mempool is above --mempool-high until it drains to --mempool-low")
+	flags.IntVar(&mempoolHigh, "mempool-high", 5000, "mempool size at which submissions are paused")
+	flags.IntVar(&mempoolLow, "mempool-low", 1000, "mempool size at which paused submissions are resumed")
+	flags.IntVar(&fundingConcurrency, "funding-concurrency", 0, "number of transactions funding the accounts and workers setting them up. Leaving as 0 funds all accounts in a single transaction")
+	flags.DurationVar(&latencyThreshold, "latency-threshold", 0, "log a warning for every transaction whose commit latency exceeds this threshold. Leaving as 0 disables the check")
+	flags.Float64Var(&maxLatencyBreaches, "max-latency-breaches", 0, "fail the run if more than this fraction of transactions exceeded --latency-threshold. Leaving as 0 never fails the run")
+	flags.StringVar(&broadcastMode, "broadcast-mode", "", "mode in which transactions are broadcast: sync (default), async for maximum throughput or block for strict ordering")
+	flags.BoolVar(&tracing, "tracing", false, "attach a trace id header to each submission and log it with the tx hash")
 	return flags
 }
-
-// readRange takes a string expected to be of the form "1-10" and returns the corresponding Range.
-// If only one number is set i.e. "5", the range returned is {5, 5}.
-func readRange(r string) (txsim.Range, error) {
-	if r == "" {
-		return txsim.Range{}, errors.New("range is empty")
-	}
-
-	res := strings.Split(r, "-")
-	n, err := strconv.Atoi(res[0])
-	if err != nil {
-		return txsim.Range{}, err
-	}
-	if len(res) == 1 {
-		return txsim.NewRange(n, n), nil
-	}
-	m, err := strconv.Atoi(res[1])
-	if err != nil {
-		return txsim.Range{}, err
-	}
-
-	return txsim.NewRange(n, m), nil
-}
@@ -3,9 +3,13 @@ package txsim
 import (
 	"bytes"
 	"context"
+	crand "crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
+	"math/rand"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,14 +21,28 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	"github.com/rs/zerolog/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 const defaultFee = DefaultGasLimit * appconsts.DefaultMinGasPrice
 
+// TraceIDHeader is the gRPC metadata header carrying the trace id of a
+// submission when tracing is enabled.
+const TraceIDHeader = "x-txsim-trace-id"
+
+// setupProgressInterval is the number of accounts after which the progress of
+// funding the accounts is logged.
+const setupProgressInterval = 100
+
+// revokeTimeout bounds the time taken to revoke the fee grants on shutdown.
+const revokeTimeout = time.Minute
+
 type AccountManager struct {
 	keys        keyring.Keyring
 	conn        *grpc.ClientConn
@@ -32,6 +50,56 @@ type AccountManager struct {
 	encCfg      encoding.Config
 	pollTime    time.Duration
 	useFeegrant bool
+	// endpoints is optional and, if set, is used to distribute transactions
+	// across multiple nodes
+	endpoints *endpointPool
+	retry     retryPolicy
+	// submitTimeout, if positive, bounds each submission attempt
+	submitTimeout time.Duration
+	// setupTimeout, if positive, bounds the funding of the pending accounts
+	setupTimeout time.Duration
+	// accountFunding is the minimum balance each subaccount is funded with
+	accountFunding uint64
+	// dryRun simulates transactions instead of broadcasting them
+	dryRun bool
+	// gasAdjustment, if set, is the factor by which the simulated gas of
+	// operations without a gas limit is multiplied
+	gasAdjustment float64
+	// fundingConcurrency, if greater than one, is the number of transactions
+	// that fund the pending accounts and the number of workers that set up
+	// their signers
+	fundingConcurrency int
+	// confirmationDepth, if greater than one, is the number of blocks,
+	// including the one with the transaction, to wait for after a
+	// transaction is included
+	confirmationDepth int
+	// latencyThreshold, if positive, is the commit latency above which a
+	// warning is logged
+	latencyThreshold time.Duration
+	// clock measures the commit latency of transactions
+	clock Clock
+	// tracing attaches a trace id header to each submission
+	tracing bool
+	// broadcastMode, if set, is the mode in which the subaccounts broadcast
+	// their transactions
+	broadcastMode BroadcastMode
+	// backpressure, if set, pauses submissions while the mempool is full
+	backpressure *mempoolGate
+	// squareSizes, if set, looks up the square size of the blocks that
+	// include PFBs
+	squareSizes *squareSizeCheck
+	// granters, if set, grant the fee allowances of the subaccounts instead
+	// of the master account
+	granters []types.AccAddress
+	// masterName is the keyring name of the master account from which the
+	// names of the subaccounts are derived
+	masterName string
+	// allocated counts the accounts allocated under each name prefix
+	allocated map[string]int
+	// accountsFile, if set, is where the accounts are persisted between runs
+	accountsFile string
+	// persisted are the accounts loaded from the accounts file by address
+	persisted map[string]persistedAccount
 
 	// to protect from concurrent writes to the map
 	mtx          sync.Mutex
@@ -40,6 +108,16 @@ type AccountManager struct {
 	latestHeight uint64
 	lastUpdated  time.Time
 	subaccounts  map[string]*user.Signer
+	// fundings records the balance each subaccount was initially funded with
+	fundings map[string]uint64
+	// feeGranters records the account paying the fees of each subaccount
+	feeGranters map[string]types.AccAddress
+	// names records the keyring name of each allocated account
+	names map[string]string
+	// pollJitter, if positive, randomizes the poll time of each sequence's
+	// accounts within ±pollJitter of the poll time using jitterRand
+	pollJitter float64
+	jitterRand *rand.Rand
 }
 
 func NewAccountManager(
@@ -63,11 +141,16 @@ func NewAccountManager(
 	am := &AccountManager{
 		keys:        keys,
 		subaccounts: make(map[string]*user.Signer),
+		fundings:    make(map[string]uint64),
+		feeGranters: make(map[string]types.AccAddress),
+		allocated:   make(map[string]int),
+		names:       make(map[string]string),
 		encCfg:      encCfg,
 		pending:     make([]*account, 0),
 		conn:        conn,
 		pollTime:    pollTime,
 		useFeegrant: useFeegrant,
+		clock:       realClock{},
 	}
 
 	if masterAccName == "" {
@@ -80,6 +163,7 @@ func NewAccountManager(
 	if err := am.setupMasterAccount(ctx, masterAccName); err != nil {
 		return nil, err
 	}
+	am.masterName = masterAccName
 
 	return am, nil
 }
@@ -151,6 +235,7 @@ func (am *AccountManager) setupMasterAccount(ctx context.Context, masterAccName
 
 	log.Info().
 		Str("address", am.master.Address().String()).
+		Str("chain id", am.master.ChainID()).
 		Uint64("balance", am.balance).
 		Msg("set master account")
 
@@ -158,8 +243,31 @@ func (am *AccountManager) setupMasterAccount(ctx context.Context, masterAccName
 }
 
 // AllocateAccounts is used by sequences to specify the number of accounts
-// and the balance of each of those accounts. Not concurrently safe.
+// and the balance of each of those accounts. Accounts are named after the
+// master account and the order in which they were allocated, i.e. "master-3".
+// Not concurrently safe.
 func (am *AccountManager) AllocateAccounts(n, balance int) []types.AccAddress {
+	return am.allocateAccounts(am.masterName, n, balance, am.pollTime)
+}
+
+// SequenceAllocator returns the AccountAllocator for the sequence with the
+// given id. Accounts are named after the master account, the sequence id and
+// their index within the sequence, i.e. "master-seq3-7", so that the same run
+// configuration always produces the same keyring entries. If poll jitter is
+// set, all accounts of the sequence share the same jittered poll time.
+func (am *AccountManager) SequenceAllocator(seqID int) AccountAllocator {
+	pollTime := am.jitteredPollTime()
+	return func(n, balance int) []types.AccAddress {
+		return am.allocateAccounts(sequencePrefix(am.masterName, seqID), n, balance, pollTime)
+	}
+}
+
+// allocateAccounts allocates n accounts named after the prefix and their index
+// among the accounts allocated with the same prefix. An account that already
+// exists in the keyring under the derived name is reused and is only funded if
+// its balance is below the requested balance. The signers of the
+// accounts poll for confirmations at the given poll time.
+func (am *AccountManager) allocateAccounts(prefix string, n, balance int, pollTime time.Duration) []types.AccAddress {
 	if n < 1 {
 		panic("n must be greater than 0")
 	}
@@ -170,18 +278,28 @@ func (am *AccountManager) AllocateAccounts(n, balance int) []types.AccAddress {
 	path := hd.CreateHDPath(types.CoinType, 0, 0).String()
 	addresses := make([]types.AccAddress, n)
 	for i := 0; i < n; i++ {
-		record, _, err := am.keys.NewMnemonic(am.nextAccountName(), keyring.English, path, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+		name := am.nextAccountName(prefix)
+		record, err := am.keys.Key(name)
+		existing := err == nil
 		if err != nil {
-			panic(err)
+			record, _, err = am.keys.NewMnemonic(name, keyring.English, path, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+			if err != nil {
+				panic(err)
+			}
 		}
 		addresses[i], err = record.GetAddress()
 		if err != nil {
 			panic(err)
 		}
 
+		am.mtx.Lock()
+		am.names[addresses[i].String()] = name
+		am.mtx.Unlock()
 		am.pending = append(am.pending, &account{
-			address: addresses[i],
-			balance: uint64(balance),
+			address:  addresses[i],
+			balance:  max(uint64(balance), am.accountFunding),
+			pollTime: pollTime,
+			existing: existing,
 		})
 	}
 	return addresses
@@ -189,39 +307,176 @@ func (am *AccountManager) AllocateAccounts(n, balance int) []types.AccAddress {
 
 // Submit executes on an operation. This is thread safe.
 func (am *AccountManager) Submit(ctx context.Context, op Operation) error {
+	_, err := am.submit(ctx, op)
+	return err
+}
+
+// submitResult contains the details of a committed operation.
+type submitResult struct {
+	// latency is the time taken from signing the transaction to it being
+	// committed. Any delay specified in the operation is not included.
+	latency time.Duration
+	// nonce is the sequence number of the signer used for the transaction
+	nonce    uint64
+	response *types.TxResponse
+	// squareSize is the square size of the block that includes a PFB. It is
+	// zero if it isn't checked.
+	squareSize uint64
+	// breached is true if the latency exceeded the latency threshold
+	breached bool
+}
+
+// submit executes on an operation and returns the details of the committed
+// transaction.
+func (am *AccountManager) submit(ctx context.Context, op Operation) (submitResult, error) {
+	signer, opts, err := am.prepare(ctx, &op)
+	if err != nil {
+		return submitResult{}, err
+	}
+	address := signer.Address()
+
+	if am.dryRun {
+		return am.simulate(ctx, signer, op, opts)
+	}
+
+	// wait for the mempool to drain if it was observed to be full
+	if err := am.backpressure.wait(ctx); err != nil {
+		return submitResult{}, err
+	}
+
+	// the trace id is shared by all attempts of the same operation
+	var traceID string
+	if am.tracing {
+		traceID, err = newTraceID()
+		if err != nil {
+			return submitResult{}, err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, TraceIDHeader, traceID)
+	}
+
+	// only the broadcast is retried. Once the transaction has been accepted
+	// into the mempool it may still be committed so it is never submitted a
+	// second time.
+	start := am.clock.Now()
+	var res *types.TxResponse
+	for attempt := 1; ; attempt++ {
+		res, err = am.broadcastWithResync(ctx, signer, op, opts)
+		if err == nil || attempt >= am.retry.maxAttempts || !isRetryable(res, err) {
+			break
+		}
+		delay := am.retry.delay(attempt)
+		log.Warn().
+			Err(err).
+			Int("attempt", attempt).
+			Dur("backoff", delay).
+			Str("address", address.String()).
+			Msg("retrying tx submission")
+		if err := sleep(ctx, delay); err != nil {
+			return submitResult{}, err
+		}
+	}
+	if err != nil {
+		// the response, if any, contains the code of a rejected transaction
+		return submitResult{response: res}, err
+	}
+
+	result, err := am.confirm(ctx, &pendingTx{
+		signer:    signer,
+		cosigners: op.cosigners,
+		start:     start,
+		// NOTE: this assumes that there are no other transactions from the
+		// signer submitted concurrently
+		nonce:    signer.LocalSequence() - 1,
+		hash:     res.TxHash,
+		hasBlobs: len(op.Blobs) > 0,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	event := log.Debug().
+		Int64("height", result.response.Height).
+		Str("address", address.String()).
+		Str("msgs", msgsToString(op.Msgs)).
+		Str("tx hash", result.response.TxHash).
+		Dur("latency", result.latency)
+	if traceID != "" {
+		event = event.Str("trace id", traceID)
+	}
+	event.Msg("tx committed")
+	return result, nil
+}
+
+// prepare validates the operation, waits for its delay and returns the signer
+// and the options with which it is submitted. The gas limit of the operation is
+// set if it is simulated.
+func (am *AccountManager) prepare(ctx context.Context, op *Operation) (*user.Signer, []user.TxOption, error) {
 	if len(op.Msgs) == 0 {
-		return errors.New("operation must contain at least one message")
+		return nil, nil, errors.New("operation must contain at least one message")
 	}
 
-	var address types.AccAddress
+	// the signers in the order in which they sign the transaction
+	var addresses []types.AccAddress
 	for _, msg := range op.Msgs {
 		if err := msg.ValidateBasic(); err != nil {
-			return fmt.Errorf("error validating message: %w", err)
+			return nil, nil, fmt.Errorf("error validating message: %w", err)
 		}
 
 		signers := msg.GetSigners()
-		if len(signers) != 1 {
-			return fmt.Errorf("only a single signer is supported got: %d", len(signers))
+		if len(signers) == 0 {
+			return nil, nil, errors.New("message has no signers")
 		}
-
-		if address == nil {
-			address = signers[0]
+		for _, signer := range signers {
+			if !containsAddress(addresses, signer) {
+				addresses = append(addresses, signer)
+			}
 		}
 	}
+	if len(addresses) > 1 && len(op.Blobs) > 0 {
+		return nil, nil, fmt.Errorf("operations with blobs must have a single signer, got %d", len(addresses))
+	}
+	if len(addresses) > 1 && op.corruptSignature {
+		return nil, nil, errors.New("the signatures of operations with several signers can't be corrupted")
+	}
+	address := addresses[0]
 
 	// If a delay is set, wait for that many blocks to have been produced
-	// before continuing
-	if op.Delay != 0 {
+	// before continuing. Delays are skipped in dry runs.
+	if op.Delay != 0 && !am.dryRun {
 		if err := am.waitDelay(ctx, op.Delay); err != nil {
-			return fmt.Errorf("error delaying tx submission: %w", err)
+			return nil, nil, fmt.Errorf("error delaying tx submission: %w", err)
 		}
 	}
 
 	signer, err := am.getSubAccount(address)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+	op.cosigners = nil
+	for _, cosignerAddress := range addresses[1:] {
+		cosigner, err := am.getSubAccount(cosignerAddress)
+		if err != nil {
+			return nil, nil, err
+		}
+		op.cosigners = append(op.cosigners, cosigner)
+	}
+
+	// Operations without a gas limit are simulated if a gas adjustment is
+	// set. Dry runs simulate every operation anyway.
+	if op.GasLimit == 0 && am.gasAdjustment != 0 && !am.dryRun {
+		gas, err := estimateGas(ctx, signer, *op, am.txOptions(address, *op))
+		if err != nil {
+			return nil, nil, fmt.Errorf("simulating tx: %w", err)
+		}
+		op.GasLimit = am.adjustGas(gas)
 	}
 
+	return signer, am.txOptions(address, *op), nil
+}
+
+// txOptions returns the gas limit, fee, fee granter and memo with which the
+// operation is submitted. Operations without a gas limit use DefaultGasLimit.
+func (am *AccountManager) txOptions(address types.AccAddress, op Operation) []user.TxOption {
 	opts := make([]user.TxOption, 0)
 	if op.GasLimit == 0 {
 		opts = append(opts, user.SetGasLimit(DefaultGasLimit), user.SetFee(defaultFee))
@@ -234,90 +489,827 @@ func (am *AccountManager) Submit(ctx context.Context, op Operation) error {
 		}
 	}
 
-	if am.useFeegrant {
-		opts = append(opts, user.SetFeeGranter(am.master.Address()))
+	if granter := am.feeGranter(address); granter != nil {
+		opts = append(opts, user.SetFeeGranter(granter))
+	}
+	if op.Memo != "" {
+		opts = append(opts, user.SetMemo(op.Memo))
+	}
+	return opts
+}
+
+// adjustGas multiplies the simulated gas by the gas adjustment, rounding up.
+func (am *AccountManager) adjustGas(gas uint64) uint64 {
+	if am.gasAdjustment == 0 {
+		return gas
 	}
+	return uint64(math.Ceil(float64(gas) * am.gasAdjustment))
+}
 
-	var res *types.TxResponse
-	if len(op.Blobs) > 0 {
-		res, err = signer.SubmitPayForBlob(ctx, op.Blobs, opts...)
-	} else {
-		res, err = signer.SubmitTx(ctx, op.Msgs, opts...)
+// simulate estimates the gas used by the operation without broadcasting it.
+// Failed simulations are logged rather than returned as the operation may
+// depend on state that, in a dry run, is never committed.
+func (am *AccountManager) simulate(ctx context.Context, signer *user.Signer, op Operation, opts []user.TxOption) (submitResult, error) {
+	start := am.clock.Now()
+	gas, err := estimateGas(ctx, signer, op, opts)
+	if ctx.Err() != nil {
+		return submitResult{}, ctx.Err()
 	}
+	latency := am.clock.Now().Sub(start)
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("address", signer.Address().String()).
+			Str("msgs", msgsToString(op.Msgs)).
+			Msg("dry run: tx simulation failed")
+		return submitResult{latency: latency}, nil
+	}
+
+	log.Debug().
+		Uint64("gas estimate", gas).
+		Uint64("adjusted gas", am.adjustGas(gas)).
+		Str("address", signer.Address().String()).
+		Str("msgs", msgsToString(op.Msgs)).
+		Msg("dry run: tx simulated")
+
+	return submitResult{latency: latency, nonce: signer.LocalSequence()}, nil
+}
+
+// broadcast signs and broadcasts the operation without waiting for it to be
+// committed. If the node can't be reached, the transaction never entered the
+// mempool so the signer's sequence number is reset to be reused. If multiple
+// endpoints are configured, each submission is sent to the next healthy
+// endpoint. If an endpoint can't be reached, it is marked as unhealthy and the
+// transaction is broadcast to the next endpoint.
+func (am *AccountManager) broadcast(ctx context.Context, signer *user.Signer, op Operation, opts []user.TxOption) (*types.TxResponse, error) {
+	attempts := 1
+	if am.endpoints != nil {
+		attempts = am.endpoints.size()
+	}
+
+	var (
+		res *types.TxResponse
+		err error
+	)
+	for attempt := 0; attempt < attempts; attempt++ {
+		var target *endpoint
+		if am.endpoints != nil {
+			target = am.endpoints.pick()
+			signer.SetConn(target.conn)
+		}
+		sequence := signer.LocalSequence()
+
+		res, err = am.broadcastOnly(ctx, signer, op, opts)
+		if !isUnreachable(err) {
+			break
+		}
+
+		// the transaction never reached the node so the sequence can be reused
+		if signer.LocalSequence() == sequence+1 {
+			signer.ForceSetSequence(sequence)
+		}
+		if target == nil {
+			break
+		}
+		log.Warn().
+			Err(err).
+			Str("endpoint", target.address).
+			Msg("endpoint unreachable, marking as unhealthy")
+		am.endpoints.markUnhealthy(target)
+	}
+	return res, err
+}
+
+// broadcastWithTimeout broadcasts the operation within the submit timeout, if
+// set. Cancellation of the parent context takes precedence over the timeout. A
+// broadcast that times out may still have reached the mempool.
+func (am *AccountManager) broadcastWithTimeout(ctx context.Context, signer *user.Signer, op Operation, opts []user.TxOption) (*types.TxResponse, error) {
+	if am.submitTimeout <= 0 {
+		return am.broadcast(ctx, signer, op, opts)
+	}
+
+	submitCtx, cancel := context.WithTimeout(ctx, am.submitTimeout)
+	defer cancel()
+	res, err := am.broadcast(submitCtx, signer, op, opts)
+	if err != nil && ctx.Err() == nil && errors.Is(submitCtx.Err(), context.DeadlineExceeded) {
+		return res, fmt.Errorf("%w after %v: %v", ErrSubmitTimeout, am.submitTimeout, err)
+	}
+	return res, err
+}
+
+// broadcastWithResync broadcasts the operation and, if it is rejected because
+// the signer's sequence number has drifted from the on-chain state (i.e. after
+// a dropped transaction), resyncs the sequence number with the chain and
+// retries the submission once.
+func (am *AccountManager) broadcastWithResync(ctx context.Context, signer *user.Signer, op Operation, opts []user.TxOption) (*types.TxResponse, error) {
+	res, err := am.broadcastWithTimeout(ctx, signer, op, opts)
+	if !isNonceMismatch(res, err) {
+		return res, err
+	}
+
+	stale := signer.LocalSequence()
+	if err := am.resyncSigners(ctx, signer, op); err != nil {
+		return res, fmt.Errorf("resyncing sequence after mismatch: %w", err)
+	}
+	log.Warn().
+		Str("address", signer.Address().String()).
+		Uint64("stale sequence", stale).
+		Uint64("sequence", signer.LocalSequence()).
+		Msg("account sequence mismatch, resynced with chain")
+	return am.broadcastWithTimeout(ctx, signer, op, opts)
+}
+
+// resyncSequence sets the signer's sequence number to the one queried from the
+// chain rather than trusting the sequence number reported in the error.
+func (am *AccountManager) resyncSequence(ctx context.Context, signer *user.Signer) error {
+	_, sequence, err := user.QueryAccount(ctx, am.conn, am.encCfg, signer.Address().String())
 	if err != nil {
 		return err
 	}
+	signer.ForceSetSequence(sequence)
+	return nil
+}
 
-	// update the latest latestHeight
-	am.setLatestHeight(res.Height)
+// resyncSigners resyncs the sequence numbers of the operation's signer and
+// cosigners, if any, with the chain. A rejected transaction with several
+// signers doesn't tell which of them drifted.
+func (am *AccountManager) resyncSigners(ctx context.Context, signer *user.Signer, op Operation) error {
+	if err := am.resyncSequence(ctx, signer); err != nil {
+		return err
+	}
+	for _, cosigner := range op.cosigners {
+		if err := am.resyncSequence(ctx, cosigner); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	log.Info().
+// newTraceID returns a random 16 byte hex encoded trace id.
+func newTraceID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := crand.Read(id); err != nil {
+		return "", fmt.Errorf("generating trace id: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// confirmTx waits for the broadcast transaction to be committed, updating the
+// sequence numbers of its signer and cosigners, if any.
+func confirmTx(ctx context.Context, signer *user.Signer, cosigners []*user.Signer, txHash string) (*types.TxResponse, error) {
+	if len(cosigners) > 0 {
+		return multiSigner(signer, cosigners).ConfirmTx(ctx, txHash)
+	}
+	return signer.ConfirmTx(ctx, txHash)
+}
+
+// isCommitted queries whether the transaction with the hash has been committed.
+// Like Signer.ConfirmTx, it relies on the error of a transaction that isn't
+// found.
+func (am *AccountManager) isCommitted(ctx context.Context, txHash string) (bool, error) {
+	_, err := sdktx.NewServiceClient(am.conn).GetTx(ctx, &sdktx.GetTxRequest{Hash: txHash})
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// broadcastOnly signs and broadcasts the operation without waiting for it to
+// be committed. If the operation is marked to have its signature corrupted,
+// the bytes of the signature are inverted so that it fails signature
+// verification.
+func (am *AccountManager) broadcastOnly(ctx context.Context, signer *user.Signer, op Operation, opts []user.TxOption) (*types.TxResponse, error) {
+	if len(op.Blobs) > 0 {
+		return signer.BroadcastPayForBlob(ctx, op.Blobs, opts...)
+	}
+	if len(op.cosigners) > 0 {
+		return multiSigner(signer, op.cosigners).BroadcastTx(ctx, op.Msgs, opts...)
+	}
+	tx, err := signer.CreateTx(op.Msgs, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if !op.corruptSignature {
+		return signer.BroadcastTx(ctx, tx)
+	}
+
+	sigs, err := tx.GetSignaturesV2()
+	if err != nil {
+		return nil, err
+	}
+	for i, sig := range sigs {
+		data, ok := sig.Data.(*signing.SingleSignatureData)
+		if !ok {
+			continue
+		}
+		corrupted := make([]byte, len(data.Signature))
+		for j, b := range data.Signature {
+			corrupted[j] = ^b
+		}
+		sigs[i].Data = &signing.SingleSignatureData{SignMode: data.SignMode, Signature: corrupted}
+	}
+	builder, err := am.encCfg.TxConfig.WrapTxBuilder(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := builder.SetSignatures(sigs...); err != nil {
+		return nil, err
+	}
+	return signer.BroadcastTx(ctx, builder.GetTx())
+}
+
+// multiSigner returns a MultiSigner for the transactions of an operation that
+// is signed by several accounts. The signer pays the fee.
+func multiSigner(signer *user.Signer, cosigners []*user.Signer) *user.MultiSigner {
+	return user.NewMultiSigner(append([]*user.Signer{signer}, cosigners...)...)
+}
+
+// estimateGas simulates the operation and returns the gas it used.
+func estimateGas(ctx context.Context, signer *user.Signer, op Operation, opts []user.TxOption) (uint64, error) {
+	if len(op.cosigners) > 0 {
+		return multiSigner(signer, op.cosigners).EstimateGas(ctx, op.Msgs, opts...)
+	}
+	return signer.EstimateGas(ctx, op.Msgs, opts...)
+}
+
+// containsAddress reports whether the address is one of the addresses.
+func containsAddress(addresses []types.AccAddress, address types.AccAddress) bool {
+	for _, a := range addresses {
+		if a.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
+
+func (am *AccountManager) setEndpoints(endpoints *endpointPool) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.endpoints = endpoints
+}
+
+// Close closes the connections to the endpoints that transactions are
+// distributed across, if set. The connection the manager was created with is
+// owned by the caller and left open.
+func (am *AccountManager) Close() error {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	if am.endpoints == nil {
+		return nil
+	}
+	return am.endpoints.Close()
+}
+
+func (am *AccountManager) setRetryPolicy(policy retryPolicy) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.retry = policy
+}
+
+func (am *AccountManager) setSubmitTimeout(timeout time.Duration) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.submitTimeout = timeout
+}
+
+func (am *AccountManager) setSetupTimeout(timeout time.Duration) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.setupTimeout = timeout
+}
+
+func (am *AccountManager) setAccountFunding(amount uint64) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.accountFunding = amount
+}
+
+func (am *AccountManager) setDryRun(dryRun bool) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.dryRun = dryRun
+}
+
+// setPollJitter randomizes the poll time of each sequence within ±frac of the
+// poll time. The jitter is drawn from a source seeded with seed so that it is
+// deterministic.
+func (am *AccountManager) setPollJitter(frac float64, seed int64) error {
+	if frac < 0 || frac >= 1 {
+		return fmt.Errorf("poll jitter must be in the range [0, 1), got %v", frac)
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.pollJitter = frac
+	am.jitterRand = rand.New(rand.NewSource(seed))
+	return nil
+}
+
+// jitteredPollTime returns the poll time randomized by the poll jitter. It
+// returns the poll time unchanged if no jitter is set.
+func (am *AccountManager) jitteredPollTime() time.Duration {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	if am.pollJitter <= 0 {
+		return am.pollTime
+	}
+	factor := 1 + am.pollJitter*(2*am.jitterRand.Float64()-1)
+	return time.Duration(float64(am.pollTime) * factor)
+}
+
+// setGasAdjustment simulates the gas of operations without a gas limit and
+// multiplies it by factor, which must be at least 1.
+func (am *AccountManager) setGasAdjustment(factor float64) error {
+	if factor < 1 {
+		return fmt.Errorf("gas adjustment must be at least 1, got %v", factor)
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.gasAdjustment = factor
+	return nil
+}
+
+func (am *AccountManager) setFundingConcurrency(n int) error {
+	if n < 0 {
+		return fmt.Errorf("funding concurrency must not be negative, got %d", n)
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.fundingConcurrency = n
+	return nil
+}
+
+func (am *AccountManager) setConfirmationDepth(depth int) error {
+	if depth < 0 {
+		return fmt.Errorf("confirmation depth must not be negative, got %d", depth)
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.confirmationDepth = depth
+	return nil
+}
+
+func (am *AccountManager) setLatencyThreshold(threshold time.Duration) error {
+	if threshold < 0 {
+		return fmt.Errorf("latency threshold must not be negative, got %v", threshold)
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.latencyThreshold = threshold
+	return nil
+}
+
+// checkLatency returns whether the commit latency of the transaction exceeds
+// the latency threshold, if set, logging a warning if it does.
+func (am *AccountManager) checkLatency(latency time.Duration, res *types.TxResponse) bool {
+	if am.latencyThreshold <= 0 || latency <= am.latencyThreshold {
+		return false
+	}
+	log.Warn().
 		Int64("height", res.Height).
-		Str("address", address.String()).
-		Str("msgs", msgsToString(op.Msgs)).
-		Msg("tx committed")
+		Str("tx hash", res.TxHash).
+		Dur("latency", latency).
+		Dur("threshold", am.latencyThreshold).
+		Msg("commit latency exceeded threshold")
+	return true
+}
+
+func (am *AccountManager) setTracing(tracing bool) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.tracing = tracing
+}
 
+// verifyChainID checks that the expected chain id matches the one reported by
+// the node when the master account was set up. All transactions are signed
+// with the node's chain id so a mismatch means the client is pointed at the
+// wrong network. An empty chain id skips the check.
+func (am *AccountManager) verifyChainID(chainID string) error {
+	if chainID == "" {
+		return nil
+	}
+	if nodeChainID := am.master.ChainID(); nodeChainID != chainID {
+		return fmt.Errorf("chain id mismatch: expected %s, node reports %s", chainID, nodeChainID)
+	}
 	return nil
 }
 
-// Generate the pending accounts by sending the adequate funds. This operation
-// is not concurrently safe.
+// Generate the pending accounts by sending the adequate funds. If a setup
+// timeout is set and exceeded, ErrSetupTimeout is returned along with the
+// number of accounts that were funded. If the context is cancelled, no further
+// transactions are submitted and the context's error is returned. This
+// operation is not concurrently safe.
 func (am *AccountManager) GenerateAccounts(ctx context.Context) error {
 	if len(am.pending) == 0 {
 		return nil
 	}
+	if am.setupTimeout <= 0 {
+		return am.generateAccounts(ctx, new(int))
+	}
+
+	total := len(am.pending)
+	ctx, cancel := context.WithTimeout(ctx, am.setupTimeout)
+	defer cancel()
+	var funded int
+	err := am.generateAccounts(ctx, &funded)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %v: funded %d/%d accounts: %v", ErrSetupTimeout, am.setupTimeout, funded, total, err)
+	}
+	return err
+}
 
-	msgs := make([]types.Msg, 0)
-	gasLimit := 0
-	// batch together all the messages needed to create all the accounts
+// generateAccounts funds and initializes the pending accounts. funded is
+// incremented for every account that is initialized. The context is checked
+// between each step so that no further transactions are submitted once it is
+// cancelled.
+func (am *AccountManager) generateAccounts(ctx context.Context, funded *int) error {
+	var (
+		fundings     []Operation
+		totalFunding uint64
+		gasLimit     uint64
+	)
+	// collect the messages needed to create each of the accounts
 	for _, acc := range am.pending {
-		if am.balance < acc.balance {
-			return fmt.Errorf("master account has insufficient funds. has: %v needed: %v", am.balance, acc.balance)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		// accounts persisted by a previous run are only topped up
+		funding, err := am.requiredFunding(ctx, acc)
+		if err != nil {
+			return err
 		}
+		totalFunding += funding
 
-		if am.useFeegrant {
+		var op Operation
+		// granters grant the allowances themselves once they are funded
+		needsAllowance := am.useFeegrant && len(am.granters) == 0
+		if needsAllowance && acc.existing {
+			// the allowance may have been granted in a previous run
+			exists, err := am.hasAllowance(ctx, am.master.Address(), acc.address)
+			if err != nil {
+				return err
+			}
+			needsAllowance = !exists
+		}
+		if needsAllowance {
 			// create a feegrant message so that the master account pays for all the fees of the sub accounts
 			feegrantMsg, err := feegrant.NewMsgGrantAllowance(&feegrant.BasicAllowance{}, am.master.Address(), acc.address)
 			if err != nil {
 				return fmt.Errorf("error creating feegrant message: %w", err)
 			}
-			msgs = append(msgs, feegrantMsg)
-			gasLimit += FeegrantGasLimit
+			op.Msgs = append(op.Msgs, feegrantMsg)
+			op.GasLimit += FeegrantGasLimit
 		}
 
-		bankMsg := bank.NewMsgSend(am.master.Address(), acc.address, types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, int64(acc.balance))))
-		msgs = append(msgs, bankMsg)
-		gasLimit += SendGasLimit
+		if funding > 0 {
+			bankMsg := bank.NewMsgSend(am.master.Address(), acc.address, types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, int64(funding))))
+			op.Msgs = append(op.Msgs, bankMsg)
+			op.GasLimit += SendGasLimit
+		}
+		if len(op.Msgs) > 0 {
+			fundings = append(fundings, op)
+			gasLimit += op.GasLimit
+		}
 	}
 
-	err := am.Submit(ctx, Operation{Msgs: msgs, GasLimit: uint64(gasLimit)})
-	if err != nil {
+	// fail before any account is funded if the master account can't fund them all
+	if err := am.checkFunding(len(am.pending), totalFunding, gasLimit); err != nil {
+		return err
+	}
+
+	if err := am.fund(ctx, fundings); err != nil {
 		return fmt.Errorf("error funding accounts: %w", err)
 	}
 
-	// check that the account now exists
+	// check that the accounts now exist
+	if err := am.setupSubAccounts(ctx, funded); err != nil {
+		return err
+	}
+
+	if am.useFeegrant && len(am.granters) > 0 {
+		if err := am.grantFeeAllowances(ctx); err != nil {
+			return err
+		}
+	}
+
+	// clear the pending accounts
+	am.pending = nil
+	return nil
+}
+
+// fund submits the funding operations of the accounts. They are merged into a
+// single transaction unless a funding concurrency is set, in which case they
+// are split into that many transactions. These are broadcast one after the
+// other, in the order of the master account's sequence, and then confirmed
+// concurrently.
+func (am *AccountManager) fund(ctx context.Context, fundings []Operation) error {
+	ops := mergeOperations(fundings, am.fundingConcurrency)
+	switch len(ops) {
+	case 0:
+		return nil
+	case 1:
+		return am.Submit(ctx, ops[0])
+	default:
+		_, errs := am.submitBatch(ctx, ops)
+		return errors.Join(errs...)
+	}
+}
+
+// mergeOperations merges the operations into at most n operations of
+// consecutive operations, combining their messages and gas limits.
+func mergeOperations(ops []Operation, n int) []Operation {
+	n = min(max(n, 1), len(ops))
+	merged := make([]Operation, n)
+	for i := range merged {
+		for _, op := range ops[i*len(ops)/n : (i+1)*len(ops)/n] {
+			merged[i].Msgs = append(merged[i].Msgs, op.Msgs...)
+			merged[i].GasLimit += op.GasLimit
+		}
+	}
+	return merged
+}
+
+// setupSubAccounts sets up the signers of the pending accounts once they have
+// been funded. The signers are set up by as many workers as the funding
+// concurrency, or one after the other if it isn't set. funded is incremented
+// for every account that is set up.
+func (am *AccountManager) setupSubAccounts(ctx context.Context, funded *int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mtx      sync.Mutex
+		firstErr error
+		accounts = make(chan *account)
+	)
+	for i := 0; i < min(max(am.fundingConcurrency, 1), len(am.pending)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for acc := range accounts {
+				err := am.setupSubAccount(ctx, acc)
+
+				mtx.Lock()
+				if err != nil {
+					// stop the other workers on the first error
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					mtx.Unlock()
+					continue
+				}
+				*funded++
+				if *funded%setupProgressInterval == 0 || *funded == len(am.pending) {
+					log.Info().Msgf("funded %d/%d accounts", *funded, len(am.pending))
+				}
+				mtx.Unlock()
+			}
+		}()
+	}
+
 	for _, acc := range am.pending {
-		signer, err := user.SetupSigner(ctx, am.keys, am.conn, acc.address, am.encCfg)
-		if err != nil {
+		select {
+		case accounts <- acc:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(accounts)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// setupSubAccount sets up the signer of a funded account.
+func (am *AccountManager) setupSubAccount(ctx context.Context, acc *account) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	signer, err := am.setupSubAccountSigner(ctx, acc.address)
+	if err != nil {
+		return err
+	}
+
+	signer.SetPollTime(acc.pollTime)
+	if am.broadcastMode != "" {
+		signer.SetBroadcastMode(am.broadcastMode.proto())
+	}
+	am.restoreSequence(signer)
+
+	// set the account
+	am.mtx.Lock()
+	am.subaccounts[acc.address.String()] = signer
+	am.fundings[acc.address.String()] = acc.balance
+	if am.useFeegrant && len(am.granters) == 0 {
+		am.feeGranters[acc.address.String()] = am.master.Address()
+	}
+	am.mtx.Unlock()
+	log.Info().
+		Str("address", acc.address.String()).
+		Uint64("balance", acc.balance).
+		Uint64("account number", signer.AccountNumber()).
+		Msg("initialized account")
+	return nil
+}
+
+// checkFunding returns an error stating the shortfall if the balance of the
+// master account doesn't cover the funding of the pending accounts plus the
+// fee of the transaction that funds them and, if the master account grants the
+// fee allowances, the grants. The fees that the master account pays for the
+// subaccounts through the allowances during the run are not included.
+func (am *AccountManager) checkFunding(accounts int, funding, gasLimit uint64) error {
+	fee := uint64(math.Ceil(float64(gasLimit) * appconsts.DefaultMinGasPrice))
+	required := funding + fee
+	if am.balance >= required {
+		return nil
+	}
+	return fmt.Errorf("master account has insufficient funds to set up %d accounts: has %d%s, needs %d%s (%d%s of funding and %d%s of fees), short by %d%s",
+		accounts,
+		am.balance, appconsts.BondDenom,
+		required, appconsts.BondDenom,
+		funding, appconsts.BondDenom,
+		fee, appconsts.BondDenom,
+		required-am.balance, appconsts.BondDenom,
+	)
+}
+
+// allocateGranters allocates n accounts that grant the fee allowances of the
+// subaccounts in place of the master account. This avoids all grants, and
+// therefore all fees, depending on the master account. The balance of the
+// master account that isn't allocated to the pending accounts is split evenly
+// between the master account and the granters. This must be called after all
+// other accounts have been allocated and is not concurrently safe.
+func (am *AccountManager) allocateGranters(n int) error {
+	var allocated uint64
+	for _, acc := range am.pending {
+		allocated += acc.balance
+	}
+	if am.balance <= allocated {
+		return fmt.Errorf("master account has insufficient funds for granters. has: %v allocated: %v", am.balance, allocated)
+	}
+	funding := (am.balance - allocated) / uint64(n+1)
+	if funding == 0 {
+		return fmt.Errorf("master account has insufficient funds for %d granters", n)
+	}
+	am.granters = am.allocateAccounts(granterPrefix(am.masterName), n, int(funding), am.pollTime)
+	return nil
+}
+
+// grantFeeAllowances distributes the pending accounts among the granters in a
+// round-robin fashion. Each granter grants the allowances of its share of the
+// accounts in a single transaction.
+func (am *AccountManager) grantFeeAllowances(ctx context.Context) error {
+	am.mtx.Lock()
+	offset := len(am.feeGranters)
+	am.mtx.Unlock()
+
+	grantees := make([][]*account, len(am.granters))
+	i := offset
+	for _, acc := range am.pending {
+		if am.isGranter(acc.address) {
+			continue
+		}
+		idx := i % len(am.granters)
+		grantees[idx] = append(grantees[idx], acc)
+		i++
+	}
+
+	for idx, granter := range am.granters {
+		if err := ctx.Err(); err != nil {
 			return err
 		}
+		if len(grantees[idx]) == 0 {
+			continue
+		}
+		msgs := make([]types.Msg, 0, len(grantees[idx]))
+		for _, grantee := range grantees[idx] {
+			// the allowance may have been granted in a previous run
+			if grantee.existing {
+				exists, err := am.hasAllowance(ctx, granter, grantee.address)
+				if err != nil {
+					return err
+				}
+				if exists {
+					continue
+				}
+			}
+			msg, err := feegrant.NewMsgGrantAllowance(&feegrant.BasicAllowance{}, granter, grantee.address)
+			if err != nil {
+				return fmt.Errorf("error creating feegrant message: %w", err)
+			}
+			msgs = append(msgs, msg)
+		}
+		if len(msgs) > 0 {
+			if err := am.Submit(ctx, Operation{Msgs: msgs, GasLimit: uint64(FeegrantGasLimit * len(msgs))}); err != nil {
+				return fmt.Errorf("error granting fee allowances from %s: %w", granter, err)
+			}
+		}
 
-		signer.SetPollTime(am.pollTime)
+		am.mtx.Lock()
+		for _, grantee := range grantees[idx] {
+			am.feeGranters[grantee.address.String()] = granter
+		}
+		am.mtx.Unlock()
+		log.Info().
+			Str("granter", granter.String()).
+			Int("grantees", len(grantees[idx])).
+			Msg("granted fee allowances")
+	}
+	return nil
+}
 
-		// set the account
+// hasAllowance returns whether the granter has granted the grantee a fee
+// allowance.
+func (am *AccountManager) hasAllowance(ctx context.Context, granter, grantee types.AccAddress) (bool, error) {
+	resp, err := feegrant.NewQueryClient(am.conn).Allowances(ctx, &feegrant.QueryAllowancesRequest{Grantee: grantee.String()})
+	if err != nil {
+		return false, fmt.Errorf("error querying fee allowances of %s: %w", grantee, err)
+	}
+	for _, allowance := range resp.Allowances {
+		if allowance.Granter == granter.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// revokeFeeGrants revokes every fee allowance that was granted, with each
+// granter revoking its allowances in a single transaction. As this is only
+// called on shutdown, failures are logged rather than returned.
+func (am *AccountManager) revokeFeeGrants(ctx context.Context) {
+	am.mtx.Lock()
+	grantees := make([]string, 0, len(am.feeGranters))
+	for grantee := range am.feeGranters {
+		grantees = append(grantees, grantee)
+	}
+	// sort for a deterministic order of messages
+	sort.Strings(grantees)
+	granters := make([]string, 0)
+	msgs := make(map[string][]types.Msg)
+	for _, grantee := range grantees {
+		granter := am.feeGranters[grantee]
+		if _, ok := msgs[granter.String()]; !ok {
+			granters = append(granters, granter.String())
+		}
+		msg := feegrant.NewMsgRevokeAllowance(granter, types.MustAccAddressFromBech32(grantee))
+		msgs[granter.String()] = append(msgs[granter.String()], &msg)
+	}
+	am.mtx.Unlock()
+
+	for _, granter := range granters {
+		op := Operation{Msgs: msgs[granter], GasLimit: uint64(FeegrantGasLimit * len(msgs[granter]))}
+		if err := am.Submit(ctx, op); err != nil {
+			log.Warn().Err(err).Str("granter", granter).Msg("failed to revoke fee allowances")
+			continue
+		}
+
+		// the grantees pay their own fees from now on
 		am.mtx.Lock()
-		am.subaccounts[acc.address.String()] = signer
+		for _, msg := range msgs[granter] {
+			delete(am.feeGranters, msg.(*feegrant.MsgRevokeAllowance).Grantee)
+		}
 		am.mtx.Unlock()
 		log.Info().
-			Str("address", acc.address.String()).
-			Uint64("balance", acc.balance).
-			Uint64("account number", signer.AccountNumber()).
-			Msg("initialized account")
+			Str("granter", granter).
+			Int("grantees", len(msgs[granter])).
+			Msg("revoked fee allowances")
 	}
+}
 
-	// clear the pending accounts
-	am.pending = nil
-	return nil
+// feeGranter returns the account that pays the fees of the address or nil if
+// the address pays its own fees.
+func (am *AccountManager) feeGranter(address types.AccAddress) types.AccAddress {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	return am.feeGranters[address.String()]
+}
+
+func (am *AccountManager) isGranter(address types.AccAddress) bool {
+	for _, granter := range am.granters {
+		if granter.Equals(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// setupSubAccountSigner creates a signer for a funded subaccount. In a dry run,
+// the account is never funded and therefore doesn't exist on chain so the
+// signer is created with an account and sequence number of zero.
+func (am *AccountManager) setupSubAccountSigner(ctx context.Context, address types.AccAddress) (*user.Signer, error) {
+	if !am.dryRun {
+		return user.SetupSigner(ctx, am.keys, am.conn, address, am.encCfg)
+	}
+	return user.NewSigner(am.keys, am.conn, address, am.encCfg.TxConfig, am.master.ChainID(), 0, 0, appconsts.LatestVersion)
 }
 
 // getBalance returns the balance for the given address
@@ -367,6 +1359,32 @@ func (am *AccountManager) waitDelay(ctx context.Context, blocks uint64) error {
 	}
 }
 
+// waitConfirmations waits until the chain has reached the height at which the
+// block at the given height has the configured number of confirmations,
+// counting itself. It returns immediately if the depth is one or less.
+func (am *AccountManager) waitConfirmations(ctx context.Context, height int64) error {
+	if am.confirmationDepth <= 1 {
+		return nil
+	}
+	target := uint64(height) + uint64(am.confirmationDepth) - 1
+	ticker := time.NewTicker(am.pollTime)
+	defer ticker.Stop()
+	for {
+		latestHeight, err := am.updateHeight(ctx)
+		if err != nil {
+			return err
+		}
+		if latestHeight >= target {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
 func (am *AccountManager) setLatestHeight(height int64) uint64 {
 	am.mtx.Lock()
 	defer am.mtx.Unlock()
@@ -391,18 +1409,32 @@ func (am *AccountManager) updateHeight(ctx context.Context) (uint64, error) {
 	return am.setLatestHeight(resp.SdkBlock.Header.Height), nil
 }
 
-func (am *AccountManager) nextAccountName() string {
+// nextAccountName returns the name of the next account allocated with the
+// prefix.
+func (am *AccountManager) nextAccountName(prefix string) string {
 	am.mtx.Lock()
 	defer am.mtx.Unlock()
-	return accountName(len(am.pending) + len(am.subaccounts))
+	index := am.allocated[prefix]
+	am.allocated[prefix]++
+	return accountName(prefix, index)
 }
 
 type account struct {
-	address types.AccAddress
-	balance uint64
+	address  types.AccAddress
+	balance  uint64
+	pollTime time.Duration
+	// existing is true if the key was already in the keyring, i.e. from a
+	// previous run, in which case the account may already be funded
+	existing bool
+}
+
+func accountName(prefix string, index int) string { return fmt.Sprintf("%s-%d", prefix, index) }
+
+func sequencePrefix(masterName string, seqID int) string {
+	return fmt.Sprintf("%s-seq%d", masterName, seqID)
 }
 
-func accountName(n int) string { return fmt.Sprintf("tx-sim-%d", n) }
+func granterPrefix(masterName string) string { return masterName + "-granter" }
 
 func msgsToString(msgs []types.Msg) string {
 	msgsStr := make([]string, len(msgs))
@@ -0,0 +1,379 @@
+//go:build !race
+
+// known race in testnode
+// ref: https://github.com/celestiaorg/celestia-app/issues/1369
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/v2/app"
+	"github.com/celestiaorg/celestia-app/v2/app/encoding"
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/v2/test/util/testnode"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmitResyncsStaleSequence(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestSubmitResyncsStaleSequence in short mode.")
+	}
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	am, err := NewAccountManager(ctx, cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, false)
+	require.NoError(t, err)
+
+	master := am.master.Address()
+	op := Operation{
+		Msgs: []sdk.Msg{bank.NewMsgSend(master, testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1)))},
+	}
+
+	require.NoError(t, am.Submit(ctx, op))
+	sequence := am.master.LocalSequence()
+
+	// inject a stale sequence number as if the signer's cached nonce drifted
+	am.master.ForceSetSequence(sequence - 1)
+
+	require.NoError(t, am.Submit(ctx, op))
+	require.Equal(t, sequence+1, am.master.LocalSequence())
+}
+
+func TestNewTraceID(t *testing.T) {
+	id, err := newTraceID()
+	require.NoError(t, err)
+	require.Len(t, id, 32)
+
+	other, err := newTraceID()
+	require.NoError(t, err)
+	require.NotEqual(t, id, other)
+}
+
+func TestDeterministicAccountNames(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	kr := keyring.NewInMemory(encCfg.Codec)
+	newManager := func() *AccountManager {
+		return &AccountManager{
+			keys:       kr,
+			masterName: "master",
+			allocated:  make(map[string]int),
+		}
+	}
+
+	am := newManager()
+	first := am.SequenceAllocator(0)(2, 1)
+	second := am.SequenceAllocator(3)(1, 1)
+	granters := am.allocateAccounts(granterPrefix(am.masterName), 1, 1, am.pollTime)
+
+	for _, name := range []string{"master-seq0-0", "master-seq0-1", "master-seq3-0", "master-granter-0"} {
+		_, err := kr.Key(name)
+		require.NoError(t, err, name)
+	}
+
+	// the same configuration resolves to the same accounts
+	am = newManager()
+	require.Equal(t, first, am.SequenceAllocator(0)(2, 1))
+	require.Equal(t, second, am.SequenceAllocator(3)(1, 1))
+	require.Equal(t, granters, am.allocateAccounts(granterPrefix(am.masterName), 1, 1, am.pollTime))
+}
+
+func TestRefund(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestRefund in short mode.")
+	}
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	am, err := NewAccountManager(ctx, cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, true)
+	require.NoError(t, err)
+
+	const funding = 100000
+	addresses := am.SequenceAllocator(0)(3, funding)
+	require.NoError(t, am.GenerateAccounts(ctx))
+
+	refunded, err := am.Refund(ctx)
+	require.NoError(t, err)
+	require.EqualValues(t, len(addresses)*(funding-refundFee), refunded)
+	require.Empty(t, am.feeGranters)
+	for _, address := range addresses {
+		balance, err := am.getBalance(ctx, address)
+		require.NoError(t, err)
+		require.Zero(t, balance)
+	}
+}
+
+func TestJitteredPollTime(t *testing.T) {
+	const pollTime = time.Second
+	am := &AccountManager{pollTime: pollTime}
+	require.Equal(t, pollTime, am.jitteredPollTime())
+
+	require.Error(t, am.setPollJitter(1, 1))
+	require.Error(t, am.setPollJitter(-0.1, 1))
+
+	require.NoError(t, am.setPollJitter(0.2, 1))
+	pollTimes := make([]time.Duration, 10)
+	for i := range pollTimes {
+		pollTimes[i] = am.jitteredPollTime()
+		require.GreaterOrEqual(t, pollTimes[i], 800*time.Millisecond)
+		require.LessOrEqual(t, pollTimes[i], 1200*time.Millisecond)
+	}
+
+	// the same seed produces the same poll times
+	require.NoError(t, am.setPollJitter(0.2, 1))
+	for i := range pollTimes {
+		require.Equal(t, pollTimes[i], am.jitteredPollTime())
+	}
+}
+
+func TestGasAdjustment(t *testing.T) {
+	am := &AccountManager{}
+	require.EqualValues(t, 100_000, am.adjustGas(100_000))
+
+	require.Error(t, am.setGasAdjustment(0.9))
+	require.Error(t, am.setGasAdjustment(-1))
+
+	require.NoError(t, am.setGasAdjustment(1))
+	require.EqualValues(t, 100_000, am.adjustGas(100_000))
+
+	require.NoError(t, am.setGasAdjustment(1.5))
+	require.EqualValues(t, 150_000, am.adjustGas(100_000))
+	// the adjusted gas is rounded up
+	require.EqualValues(t, 2, am.adjustGas(1))
+}
+
+func TestCheckFunding(t *testing.T) {
+	am := &AccountManager{balance: 10_000}
+	// 1000 gas at the default min gas price costs 2utia
+	require.NoError(t, am.checkFunding(2, 9_998, 1000))
+
+	err := am.checkFunding(2, 9_999, 1000)
+	require.ErrorContains(t, err, "has 10000utia, needs 10001utia (9999utia of funding and 2utia of fees), short by 1utia")
+}
+
+func TestGenerateAccountsInsufficientFunds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestGenerateAccountsInsufficientFunds in short mode.")
+	}
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(t, cfg)
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	am, err := NewAccountManager(context.Background(), cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, false)
+	require.NoError(t, err)
+	// each account can be funded on its own but not both of them
+	am.SequenceAllocator(0)(2, int(am.balance/2)+1)
+
+	sequence := am.master.LocalSequence()
+	err = am.GenerateAccounts(context.Background())
+	require.ErrorContains(t, err, "master account has insufficient funds to set up 2 accounts")
+	// no account is funded
+	require.Equal(t, sequence, am.master.LocalSequence())
+	require.Empty(t, am.subaccounts)
+}
+
+func TestMergeOperations(t *testing.T) {
+	ops := make([]Operation, 5)
+	for i := range ops {
+		ops[i] = Operation{Msgs: []sdk.Msg{&bank.MsgSend{}}, GasLimit: SendGasLimit}
+	}
+	require.Empty(t, mergeOperations(nil, 3))
+	require.Len(t, mergeOperations(ops, 0), 1)
+	require.Len(t, mergeOperations(ops, 10), 5)
+
+	merged := mergeOperations(ops, 2)
+	require.Len(t, merged, 2)
+	require.Len(t, merged[0].Msgs, 2)
+	require.Len(t, merged[1].Msgs, 3)
+	require.EqualValues(t, 3*SendGasLimit, merged[1].GasLimit)
+}
+
+func TestGenerateAccountsFundingConcurrency(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestGenerateAccountsFundingConcurrency in short mode.")
+	}
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	am, err := NewAccountManager(ctx, cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, false)
+	require.NoError(t, err)
+	require.Error(t, am.setFundingConcurrency(-1))
+	require.NoError(t, am.setFundingConcurrency(3))
+	addresses := am.SequenceAllocator(0)(10, 1000)
+
+	sequence := am.master.LocalSequence()
+	require.NoError(t, am.GenerateAccounts(ctx))
+	// the accounts are funded by one transaction per worker
+	require.Equal(t, sequence+3, am.master.LocalSequence())
+	for _, address := range addresses {
+		balance, err := am.getBalance(ctx, address)
+		require.NoError(t, err)
+		require.EqualValues(t, 1000, balance)
+		_, err = am.getSubAccount(address)
+		require.NoError(t, err)
+	}
+}
+
+// BenchmarkGenerateAccounts compares the time taken to fund and set up 1000
+// accounts with and without funding concurrency.
+func BenchmarkGenerateAccounts(b *testing.B) {
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(b, cfg)
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+
+	for _, concurrency := range []int{1, 10} {
+		b.Run(fmt.Sprintf("funding concurrency %d", concurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				am, err := NewAccountManager(context.Background(), cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, false)
+				require.NoError(b, err)
+				require.NoError(b, am.setFundingConcurrency(concurrency))
+				am.AllocateAccounts(1000, 1000)
+				require.NoError(b, am.GenerateAccounts(context.Background()))
+			}
+		})
+	}
+}
+
+func TestGenerateAccountsReusesKeyring(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestGenerateAccountsReusesKeyring in short mode.")
+	}
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	// setup funds two accounts and returns the number of transactions that
+	// the master account submitted to do so
+	setup := func(balance int) (*AccountManager, uint64) {
+		am, err := NewAccountManager(ctx, cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, true)
+		require.NoError(t, err)
+		am.SequenceAllocator(0)(2, balance)
+		sequence := am.master.LocalSequence()
+		require.NoError(t, am.GenerateAccounts(ctx))
+		return am, am.master.LocalSequence() - sequence
+	}
+
+	// the first run funds the accounts and grants the allowances
+	_, txs := setup(1000)
+	require.EqualValues(t, 1, txs)
+
+	// the same keys are reused without funding or granting them again
+	am, txs := setup(1000)
+	require.Zero(t, txs)
+	require.Len(t, am.subaccounts, 2)
+
+	// accounts below the balance are topped up
+	am, txs = setup(1500)
+	require.EqualValues(t, 1, txs)
+	for address := range am.subaccounts {
+		balance, err := am.getBalance(ctx, sdk.MustAccAddressFromBech32(address))
+		require.NoError(t, err)
+		require.EqualValues(t, 1500, balance)
+	}
+}
+
+func TestGenerateAccountsSetupTimeout(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestGenerateAccountsSetupTimeout in short mode.")
+	}
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	am, err := NewAccountManager(ctx, cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, false)
+	require.NoError(t, err)
+
+	// the funding transaction can't be committed within the timeout
+	am.setSetupTimeout(time.Millisecond)
+	am.SequenceAllocator(0)(2, 1000)
+	err = am.GenerateAccounts(ctx)
+	require.ErrorIs(t, err, ErrSetupTimeout)
+	require.ErrorContains(t, err, "funded 0/2 accounts")
+}
+
+func TestGenerateAccountsCancelled(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestGenerateAccountsCancelled in short mode.")
+	}
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(t, cfg)
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	am, err := NewAccountManager(context.Background(), cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, false)
+	require.NoError(t, err)
+	am.SequenceAllocator(0)(2, 1000)
+
+	// cancel while the funding transaction is waiting to be committed
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- am.GenerateAccounts(ctx)
+	}()
+
+	select {
+	case err := <-errCh:
+		// the error depends on which call was interrupted
+		require.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("GenerateAccounts didn't return after the context was cancelled")
+	}
+	require.Empty(t, am.subaccounts)
+	require.Len(t, am.pending, 2)
+
+	// no funding transaction is submitted once the context is cancelled
+	sequence := am.master.LocalSequence()
+	require.ErrorIs(t, am.GenerateAccounts(ctx), context.Canceled)
+	require.Equal(t, sequence, am.master.LocalSequence())
+}
+
+func TestConfirmationDepth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestConfirmationDepth in short mode.")
+	}
+	cfg := testnode.DefaultConfig().WithTimeoutCommit(300 * time.Millisecond).WithFundedAccounts("txsim-master")
+	cctx, _, _ := testnode.NewNetwork(t, cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	am, err := NewAccountManager(ctx, cctx.Keyring, encCfg, "txsim-master", cctx.GRPCClient, 100*time.Millisecond, false)
+	require.NoError(t, err)
+	require.Error(t, am.setConfirmationDepth(-1))
+	require.NoError(t, am.setConfirmationDepth(3))
+
+	master := am.master.Address()
+	res, err := am.submit(ctx, Operation{
+		Msgs: []sdk.Msg{bank.NewMsgSend(master, testnode.RandomAddress().(sdk.AccAddress), sdk.NewCoins(sdk.NewInt64Coin(appconsts.BondDenom, 1)))},
+	})
+	require.NoError(t, err)
+
+	// the block with the transaction and the two after it have been produced
+	height, err := am.updateHeight(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, height, uint64(res.response.Height)+2)
+}
@@ -0,0 +1,156 @@
+package txsim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/user"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog/log"
+)
+
+// persistedAccount is the metadata of an allocated account that is kept in the
+// accounts file between runs. The private key remains in the keyring so
+// accounts can only be reused with a keyring that is persisted as well.
+type persistedAccount struct {
+	Name     string `json:"name"`
+	Address  string `json:"address"`
+	Sequence uint64 `json:"sequence"`
+}
+
+// loadAccountsFile reads the accounts persisted at path, indexed by address. A
+// missing file is treated as empty so that the first run creates it.
+func loadAccountsFile(path string) (map[string]persistedAccount, error) {
+	accounts := make(map[string]persistedAccount)
+	bz, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return accounts, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading accounts file: %w", err)
+	}
+
+	var list []persistedAccount
+	if err := json.Unmarshal(bz, &list); err != nil {
+		return nil, fmt.Errorf("decoding accounts file %s: %w", path, err)
+	}
+	for _, acc := range list {
+		if _, err := types.AccAddressFromBech32(acc.Address); err != nil {
+			return nil, fmt.Errorf("accounts file %s: invalid address for %s: %w", path, acc.Name, err)
+		}
+		accounts[acc.Address] = acc
+	}
+	return accounts, nil
+}
+
+// writeAccountsFile writes the accounts, sorted by name, to path. The file is
+// first written to a temporary file and then renamed so that an interrupted
+// write doesn't corrupt the accounts of a previous run.
+func writeAccountsFile(path string, accounts []persistedAccount) error {
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Name < accounts[j].Name })
+	bz, err := json.MarshalIndent(accounts, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, bz, 0o600); err != nil {
+		return fmt.Errorf("writing accounts file: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// setAccountsFile loads the accounts persisted by a previous run. Allocated
+// accounts that were persisted are reused rather than funded from scratch.
+func (am *AccountManager) setAccountsFile(path string) error {
+	accounts, err := loadAccountsFile(path)
+	if err != nil {
+		return err
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.accountsFile = path
+	am.persisted = accounts
+	return nil
+}
+
+// requiredFunding returns the amount the pending account must be sent to reach
+// its balance. Accounts that were persisted or whose key was already in the
+// keyring are only topped up while new accounts need to be funded in full.
+func (am *AccountManager) requiredFunding(ctx context.Context, acc *account) (uint64, error) {
+	if _, ok := am.persisted[acc.address.String()]; !ok && !acc.existing {
+		return acc.balance, nil
+	}
+	balance, err := am.getBalance(ctx, acc.address)
+	if err != nil {
+		return 0, fmt.Errorf("error getting balance of existing account %s: %w", acc.address, err)
+	}
+	if balance >= acc.balance {
+		log.Info().
+			Str("address", acc.address.String()).
+			Uint64("balance", balance).
+			Msg("reusing existing account")
+		return 0, nil
+	}
+	return acc.balance - balance, nil
+}
+
+// restoreSequence restores the sequence of a persisted account on the signer,
+// which was set up with the sequence on chain. The larger of the two is used:
+// the persisted sequence is the last one known to be committed so a lower
+// sequence on chain means that the queried node is lagging behind, while a
+// higher one means that transactions were submitted by another client.
+func (am *AccountManager) restoreSequence(signer *user.Signer) {
+	address := signer.Address().String()
+	persisted, ok := am.persisted[address]
+	onChain := signer.NetworkSequence()
+	if !ok || persisted.Sequence <= onChain {
+		return
+	}
+	signer.ForceSetSequence(persisted.Sequence)
+	log.Info().
+		Str("address", address).
+		Uint64("persisted", persisted.Sequence).
+		Uint64("on chain", onChain).
+		Msg("restored persisted account sequence")
+}
+
+// saveAccounts writes the metadata of all accounts to the accounts file, if
+// set. Accounts persisted by previous runs that weren't allocated in this run
+// are kept as long as their key is still in the keyring.
+func (am *AccountManager) saveAccounts() error {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	if am.accountsFile == "" {
+		return nil
+	}
+
+	accounts := make(map[string]persistedAccount, len(am.persisted)+len(am.subaccounts))
+	for address, acc := range am.persisted {
+		record, err := am.keys.Key(acc.Name)
+		if err != nil {
+			continue
+		}
+		recordAddress, err := record.GetAddress()
+		if err != nil || recordAddress.String() != address {
+			continue
+		}
+		accounts[address] = acc
+	}
+	for address, signer := range am.subaccounts {
+		accounts[address] = persistedAccount{
+			Name:     am.names[address],
+			Address:  address,
+			Sequence: signer.NetworkSequence(),
+		}
+	}
+
+	list := make([]persistedAccount, 0, len(accounts))
+	for _, acc := range accounts {
+		list = append(list, acc)
+	}
+	return writeAccountsFile(am.accountsFile, list)
+}
@@ -0,0 +1,91 @@
+package txsim
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/app"
+	"github.com/celestiaorg/celestia-app/v2/app/encoding"
+	"github.com/celestiaorg/celestia-app/v2/pkg/user"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountsFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "accounts.json")
+
+	// a missing file is treated as empty
+	accounts, err := loadAccountsFile(path)
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+
+	address := types.AccAddress(make([]byte, 20)).String()
+	persisted := []persistedAccount{{Name: "master-seq0-0", Address: address, Sequence: 12}}
+	require.NoError(t, writeAccountsFile(path, persisted))
+
+	accounts, err = loadAccountsFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]persistedAccount{address: persisted[0]}, accounts)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name":"a","address":"invalid"}]`), 0o600))
+	_, err = loadAccountsFile(path)
+	require.Error(t, err)
+}
+
+func TestSaveAccountsDropsMissingKeys(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	am := &AccountManager{
+		keys:       keyring.NewInMemory(encCfg.Codec),
+		masterName: "master",
+		allocated:  make(map[string]int),
+		names:      make(map[string]string),
+	}
+	addresses := am.SequenceAllocator(0)(1, 1)
+
+	kept := persistedAccount{Name: "master-seq0-0", Address: addresses[0].String(), Sequence: 3}
+	removed := persistedAccount{Name: "removed", Address: types.AccAddress(make([]byte, 20)).String()}
+	require.NoError(t, writeAccountsFile(path, []persistedAccount{kept, removed}))
+	require.NoError(t, am.setAccountsFile(path))
+	require.NoError(t, am.saveAccounts())
+
+	accounts, err := loadAccountsFile(path)
+	require.NoError(t, err)
+	require.Equal(t, map[string]persistedAccount{kept.Address: kept}, accounts)
+}
+
+func TestRestoreSequence(t *testing.T) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	testCases := []struct {
+		name        string
+		persisted   uint64
+		onChain     uint64
+		expSequence uint64
+	}{
+		{name: "persisted sequence ahead of the chain", persisted: 12, onChain: 10, expSequence: 12},
+		{name: "chain ahead of the persisted sequence", persisted: 10, onChain: 12, expSequence: 12},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			am := &AccountManager{
+				keys:       keyring.NewInMemory(encCfg.Codec),
+				masterName: "master",
+				allocated:  make(map[string]int),
+				names:      make(map[string]string),
+			}
+			address := am.SequenceAllocator(0)(1, 1)[0]
+			am.persisted = map[string]persistedAccount{
+				address.String(): {Name: "master-seq0-0", Address: address.String(), Sequence: tc.persisted},
+			}
+
+			signer, err := user.NewSigner(am.keys, nil, address, encCfg.TxConfig, "test", 0, tc.onChain, 2)
+			require.NoError(t, err)
+			am.restoreSequence(signer)
+			require.Equal(t, tc.expSequence, signer.LocalSequence())
+			require.Equal(t, tc.expSequence, signer.NetworkSequence())
+		})
+	}
+}
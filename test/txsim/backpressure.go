@@ -0,0 +1,131 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/tendermint/tendermint/rpc/client/http"
+)
+
+// mempoolGate pauses submissions while the mempool of the node holds more
+// transactions than the high-water mark and resumes them once it drains to the
+// low-water mark. A nil gate never pauses.
+type mempoolGate struct {
+	high, low int
+
+	mtx sync.Mutex
+	// resumed is closed when submissions resume. It is nil while they are not
+	// paused.
+	resumed chan struct{}
+}
+
+func newMempoolGate(high, low int) (*mempoolGate, error) {
+	if high <= 0 || low < 0 || low >= high {
+		return nil, fmt.Errorf("mempool marks must satisfy 0 <= low < high, got low: %d high: %d", low, high)
+	}
+	return &mempoolGate{high: high, low: low}, nil
+}
+
+// wait blocks while submissions are paused or until the context is cancelled.
+func (g *mempoolGate) wait(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	g.mtx.Lock()
+	resumed := g.resumed
+	g.mtx.Unlock()
+	if resumed == nil {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-resumed:
+		return nil
+	}
+}
+
+// update pauses or resumes submissions given the number of transactions in
+// the mempool.
+func (g *mempoolGate) update(size int) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	switch {
+	case g.resumed == nil && size >= g.high:
+		g.resumed = make(chan struct{})
+		log.Info().Int("mempool size", size).Int("high", g.high).Msg("pausing submissions")
+	case g.resumed != nil && size <= g.low:
+		close(g.resumed)
+		g.resumed = nil
+		log.Info().Int("mempool size", size).Int("low", g.low).Msg("resuming submissions")
+	}
+}
+
+// resume unblocks all waiting submissions.
+func (g *mempoolGate) resume() {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	if g.resumed != nil {
+		close(g.resumed)
+		g.resumed = nil
+	}
+}
+
+// mempoolSizeFunc returns the number of transactions in the node's mempool.
+type mempoolSizeFunc func(ctx context.Context) (int, error)
+
+// rpcMempoolSize queries the number of unconfirmed transactions from the
+// node's RPC endpoint.
+func rpcMempoolSize(rpcEndpoint string) (mempoolSizeFunc, error) {
+	client, err := http.New(rpcEndpoint, "/websocket")
+	if err != nil {
+		return nil, fmt.Errorf("creating rpc client: %w", err)
+	}
+	return func(ctx context.Context) (int, error) {
+		res, err := client.NumUnconfirmedTxs(ctx)
+		if err != nil {
+			return 0, err
+		}
+		return res.Total, nil
+	}, nil
+}
+
+// setMempoolBackpressure pauses submissions while the mempool holds at least
+// high transactions until it drains to low.
+func (am *AccountManager) setMempoolBackpressure(high, low int) error {
+	gate, err := newMempoolGate(high, low)
+	if err != nil {
+		return err
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.backpressure = gate
+	return nil
+}
+
+// monitorMempool periodically queries the size of the mempool and updates the
+// gate until the context is cancelled, after which submissions are resumed so
+// that shutdown isn't blocked.
+func (am *AccountManager) monitorMempool(ctx context.Context, mempoolSize mempoolSizeFunc, interval time.Duration) {
+	defer am.backpressure.resume()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			size, err := mempoolSize(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Warn().Err(err).Msg("failed to query mempool size")
+				}
+				continue
+			}
+			am.backpressure.update(size)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package txsim
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMempoolGate(t *testing.T) {
+	_, err := newMempoolGate(10, 10)
+	require.Error(t, err)
+	_, err = newMempoolGate(0, 0)
+	require.Error(t, err)
+
+	gate, err := newMempoolGate(10, 2)
+	require.NoError(t, err)
+	require.NoError(t, gate.wait(context.Background()))
+
+	// submissions are paused once the high-water mark is reached
+	gate.update(10)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, gate.wait(ctx), context.DeadlineExceeded)
+
+	// and stay paused until the mempool drains to the low-water mark
+	gate.update(5)
+	done := make(chan error)
+	go func() { done <- gate.wait(context.Background()) }()
+	gate.update(2)
+	require.NoError(t, <-done)
+	require.NoError(t, gate.wait(context.Background()))
+
+	// a nil gate never pauses
+	var nilGate *mempoolGate
+	require.NoError(t, nilGate.wait(context.Background()))
+}
+
+func TestMonitorMempoolResumesOnShutdown(t *testing.T) {
+	am := &AccountManager{}
+	require.NoError(t, am.setMempoolBackpressure(10, 2))
+
+	var size atomic.Int64
+	size.Store(100)
+	mempoolSize := func(context.Context) (int, error) { return int(size.Load()), nil }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		am.monitorMempool(ctx, mempoolSize, time.Millisecond)
+		close(stopped)
+	}()
+
+	require.Eventually(t, func() bool {
+		waitCtx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		return am.backpressure.wait(waitCtx) != nil
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-stopped
+	require.NoError(t, am.backpressure.wait(context.Background()))
+}
@@ -0,0 +1,157 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/user"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog/log"
+)
+
+// pendingTx is a transaction that has been broadcast but not yet committed.
+type pendingTx struct {
+	signer    *user.Signer
+	cosigners []*user.Signer
+	start     time.Time
+	nonce     uint64
+	hash      string
+	hasBlobs  bool
+}
+
+// submitBatch broadcasts the operations one after the other without waiting
+// for any of them to be committed and then waits for all of them to be
+// committed concurrently. This allows several transactions of the same
+// accounts to be in the mempool at once. The signer of each account tracks the
+// sequence numbers of its outstanding transactions so they remain correct. If a
+// broadcast is rejected because the sequence number has drifted, it is resynced
+// with the chain and the broadcast is retried once. Unlike submit, failed
+// broadcasts are not otherwise retried and the endpoints are not failed over.
+// The results and errors are returned in the order of the operations.
+func (am *AccountManager) submitBatch(ctx context.Context, ops []Operation) ([]submitResult, []error) {
+	results := make([]submitResult, len(ops))
+	errs := make([]error, len(ops))
+	if am.dryRun {
+		for i, op := range ops {
+			results[i], errs[i] = am.submit(ctx, op)
+		}
+		return results, errs
+	}
+
+	pending := make([]*pendingTx, len(ops))
+	for i := range ops {
+		var res *types.TxResponse
+		pending[i], res, errs[i] = am.broadcastPending(ctx, &ops[i])
+		if errs[i] != nil {
+			results[i] = submitResult{response: res}
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i, tx := range pending {
+		if tx == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, tx *pendingTx) {
+			defer wg.Done()
+			results[i], errs[i] = am.confirm(ctx, tx)
+		}(i, tx)
+	}
+	wg.Wait()
+
+	for i, op := range ops {
+		if errs[i] != nil {
+			continue
+		}
+		log.Debug().
+			Int64("height", results[i].response.Height).
+			Str("address", pending[i].signer.Address().String()).
+			Str("msgs", msgsToString(op.Msgs)).
+			Str("tx hash", results[i].response.TxHash).
+			Dur("latency", results[i].latency).
+			Int("batch size", len(ops)).
+			Msg("tx committed")
+	}
+	return results, errs
+}
+
+// broadcastPending prepares and broadcasts the operation, returning the
+// transaction to wait for.
+func (am *AccountManager) broadcastPending(ctx context.Context, op *Operation) (*pendingTx, *types.TxResponse, error) {
+	signer, opts, err := am.prepare(ctx, op)
+	if err != nil {
+		return nil, nil, err
+	}
+	// wait for the mempool to drain if it was observed to be full
+	if err := am.backpressure.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	start := am.clock.Now()
+	res, err := am.broadcastOnly(ctx, signer, *op, opts)
+	if isNonceMismatch(res, err) {
+		if err := am.resyncSigners(ctx, signer, *op); err != nil {
+			return nil, res, fmt.Errorf("resyncing sequence after mismatch: %w", err)
+		}
+		res, err = am.broadcastOnly(ctx, signer, *op, opts)
+	}
+	if err != nil {
+		return nil, res, err
+	}
+	return &pendingTx{
+		signer:    signer,
+		cosigners: op.cosigners,
+		start:     start,
+		nonce:     signer.LocalSequence() - 1,
+		hash:      res.TxHash,
+		hasBlobs:  len(op.Blobs) > 0,
+	}, res, nil
+}
+
+// confirm waits for the transaction to be committed within the submit timeout,
+// if set. If the timeout expires, the transaction is looked up by its hash
+// once more as it may have been committed in the meantime.
+func (am *AccountManager) confirm(ctx context.Context, tx *pendingTx) (submitResult, error) {
+	confirmCtx := ctx
+	if am.submitTimeout > 0 {
+		var cancel context.CancelFunc
+		confirmCtx, cancel = context.WithTimeout(ctx, am.submitTimeout)
+		defer cancel()
+	}
+
+	res, err := confirmTx(confirmCtx, tx.signer, tx.cosigners, tx.hash)
+	if err != nil && ctx.Err() == nil && errors.Is(confirmCtx.Err(), context.DeadlineExceeded) {
+		committed, lookupErr := am.isCommitted(ctx, tx.hash)
+		switch {
+		case lookupErr != nil:
+			err = fmt.Errorf("%w after %v waiting for tx %s: %v (looking up tx: %v)", ErrSubmitTimeout, am.submitTimeout, tx.hash, err, lookupErr)
+		case committed:
+			// the transaction is found straight away
+			res, err = confirmTx(ctx, tx.signer, tx.cosigners, tx.hash)
+		default:
+			err = fmt.Errorf("%w after %v waiting for tx %s: %v", ErrSubmitTimeout, am.submitTimeout, tx.hash, err)
+		}
+	}
+	if err != nil {
+		return submitResult{response: res}, err
+	}
+	am.setLatestHeight(res.Height)
+	if err := am.waitConfirmations(ctx, res.Height); err != nil {
+		return submitResult{}, fmt.Errorf("waiting for confirmations: %w", err)
+	}
+	latency := am.clock.Now().Sub(tx.start)
+	result := submitResult{
+		latency:  latency,
+		nonce:    tx.nonce,
+		response: res,
+		breached: am.checkLatency(latency, res),
+	}
+	if tx.hasBlobs {
+		result.squareSize = am.squareSizes.check(ctx, res.Height)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSequencesBatches(t *testing.T) {
+	var batchSizes []int
+	submit := func(_ context.Context, _ Operation) (submitResult, error) {
+		batchSizes = append(batchSizes, 1)
+		return submitResult{response: &types.TxResponse{}}, nil
+	}
+	submitBatch := func(_ context.Context, ops []Operation) ([]submitResult, []error) {
+		batchSizes = append(batchSizes, len(ops))
+		results := make([]submitResult, len(ops))
+		for i := range results {
+			results[i] = submitResult{response: &types.TxResponse{}}
+		}
+		return results, make([]error, len(ops))
+	}
+
+	t.Run("batches up to the batch size", func(t *testing.T) {
+		batchSizes = nil
+		opts := DefaultOptions().WithSubmitBatchSize(4)
+		stats, err := runSequences(context.Background(), opts, nil, submit, submitBatch, nil, []Sequence{&countingSequence{length: 10}})
+		require.NoError(t, err)
+		require.Equal(t, []int{4, 4, 2}, batchSizes)
+		require.Equal(t, 10, stats[0].committed)
+	})
+
+	t.Run("respects the transaction limit", func(t *testing.T) {
+		batchSizes = nil
+		opts := DefaultOptions().WithSubmitBatchSize(4).WithTxLimit(5)
+		stats, err := runSequences(context.Background(), opts, nil, submit, submitBatch, nil, []Sequence{&countingSequence{length: 10}})
+		require.NoError(t, err)
+		// a single remaining operation is submitted on its own
+		require.Equal(t, []int{4, 1}, batchSizes)
+		require.Equal(t, 5, stats[0].committed)
+	})
+
+	t.Run("submits serially by default", func(t *testing.T) {
+		batchSizes = nil
+		_, err := runSequences(context.Background(), DefaultOptions(), nil, submit, submitBatch, nil, []Sequence{&countingSequence{length: 3}})
+		require.NoError(t, err)
+		require.Equal(t, []int{1, 1, 1}, batchSizes)
+	})
+
+	t.Run("records every result of a failed batch", func(t *testing.T) {
+		errSubmit := errors.New("submit failed")
+		failing := func(_ context.Context, ops []Operation) ([]submitResult, []error) {
+			errs := make([]error, len(ops))
+			errs[1] = errSubmit
+			return make([]submitResult, len(ops)), errs
+		}
+		opts := DefaultOptions().WithSubmitBatchSize(3)
+		stats, err := runSequences(context.Background(), opts, nil, submit, failing, nil, []Sequence{&countingSequence{length: 10}})
+		require.ErrorIs(t, err, errSubmit)
+		require.Equal(t, 2, stats[0].committed)
+		require.Equal(t, 1, stats[0].errored)
+	})
+}
+
+func TestTxBudget(t *testing.T) {
+	budget := newTxBudget(1)
+	require.True(t, budget.take())
+	// taking from an exhausted budget doesn't overdraw it
+	require.False(t, budget.take())
+	require.False(t, budget.take())
+	budget.release()
+	require.True(t, budget.take())
+	require.False(t, budget.take())
+
+	// a nil budget is unlimited
+	require.True(t, newTxBudget(0).take())
+}
@@ -24,9 +24,26 @@ type BlobSequence struct {
 	namespace   ns.Namespace
 	sizes       Range
 	blobsPerPFB Range
+	// poolSize is the number of random namespaces that blobs are drawn from.
+	// If zero, a new random namespace is generated for each blob.
+	poolSize int
+	// hotProbability is the probability that a blob's namespace is drawn from
+	// the pool rather than generated at random
+	hotProbability float64
+	namespaces     []ns.Namespace
+	// squareSize, if set, overrides the random blob sizes with sizes that
+	// fill a square of this size
+	squareSize int
+	// gas, if set, overrides the estimated gas of each PFB
+	gas fixedGas
+	// byteBudget, if set, is the total number of blob bytes after which the
+	// sequence ends
+	byteBudget int
 
 	account     types.AccAddress
 	useFeegrant bool
+	// spent is the number of blob bytes generated so far
+	spent int
 }
 
 func NewBlobSequence(sizes, blobsPerPFB Range) *BlobSequence {
@@ -43,20 +60,78 @@ func (s *BlobSequence) WithNamespace(namespace ns.Namespace) *BlobSequence {
 	return s
 }
 
+// WithNamespacePool draws the namespace of each blob from a pool of size
+// random namespaces. The pool is generated from the random source provided
+// in Init. This is ignored if a fixed namespace is set.
+func (s *BlobSequence) WithNamespacePool(size int) *BlobSequence {
+	s.poolSize = size
+	s.hotProbability = 1
+	return s
+}
+
+// WithHotNamespaces draws the namespace of each blob from a "hot" pool of size
+// random namespaces with the given probability. Otherwise, the blob is given a
+// new random namespace. This allows measuring the effect of many blobs sharing
+// a namespace compared to many distinct namespaces. The pool is generated from
+// the random source provided in Init. This is ignored if a fixed namespace is set.
+func (s *BlobSequence) WithHotNamespaces(size int, probability float64) *BlobSequence {
+	s.poolSize = size
+	s.hotProbability = probability
+	return s
+}
+
+// WithSquareSize replaces the random blob sizes with deterministic sizes that,
+// together with the PFB, fill a data square of the given size as tightly as
+// possible. This is useful for reproducing blocks of a precise layout. The
+// number of blobs per PFB is still drawn from its range.
+func (s *BlobSequence) WithSquareSize(size int) *BlobSequence {
+	s.squareSize = size
+	return s
+}
+
+// WithGas pins the gas limit and gas price of every PFB, overriding the gas
+// estimated from the blob sizes. Next fails if the gas limit is below the
+// estimate. A zero gas price uses the default minimum gas price.
+func (s *BlobSequence) WithGas(limit uint64, price float64) *BlobSequence {
+	s.gas = fixedGas{limit: limit, price: price}
+	return s
+}
+
+// WithByteBudget ends the sequence once its blobs total budget bytes, which is
+// more meaningful than a number of transactions when the blob sizes vary
+// widely. The last PFB is cut short to fit the remaining budget exactly: the
+// blobs that don't fit are dropped and the last blob is shrunk. The budget
+// applies to each clone and counts the bytes of every generated PFB, whether or
+// not it is committed.
+func (s *BlobSequence) WithByteBudget(budget int) *BlobSequence {
+	s.byteBudget = budget
+	return s
+}
+
 func (s *BlobSequence) Clone(n int) []Sequence {
 	sequenceGroup := make([]Sequence, n)
 	for i := 0; i < n; i++ {
 		sequenceGroup[i] = &BlobSequence{
-			namespace:   s.namespace,
-			sizes:       s.sizes,
-			blobsPerPFB: s.blobsPerPFB,
+			namespace:      s.namespace,
+			sizes:          s.sizes,
+			blobsPerPFB:    s.blobsPerPFB,
+			poolSize:       s.poolSize,
+			hotProbability: s.hotProbability,
+			squareSize:     s.squareSize,
+			gas:            s.gas,
+			byteBudget:     s.byteBudget,
 		}
 	}
 	return sequenceGroup
 }
 
-func (s *BlobSequence) Init(_ context.Context, _ grpc.ClientConn, allocateAccounts AccountAllocator, _ *rand.Rand, useFeegrant bool) {
+func (s *BlobSequence) Init(_ context.Context, _ grpc.ClientConn, allocateAccounts AccountAllocator, rand *rand.Rand, useFeegrant bool) {
 	s.useFeegrant = useFeegrant
+	s.namespaces = make([]ns.Namespace, s.poolSize)
+	for i := range s.namespaces {
+		// NOTE: reading from a math/rand source never returns an error
+		s.namespaces[i], _ = randomNamespace(rand)
+	}
 	funds := fundsForGas
 	if useFeegrant {
 		funds = 1
@@ -65,22 +140,27 @@ func (s *BlobSequence) Init(_ context.Context, _ grpc.ClientConn, allocateAccoun
 }
 
 func (s *BlobSequence) Next(_ context.Context, _ grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	if s.byteBudget > 0 && s.spent >= s.byteBudget {
+		return Operation{}, ErrEndOfSequence
+	}
 	numBlobs := s.blobsPerPFB.Rand(rand)
 	sizes := make([]int, numBlobs)
+	if s.squareSize > 0 {
+		sizes = squareBlobSizes(s.squareSize, numBlobs)
+	}
 	namespaces := make([]ns.Namespace, numBlobs)
 	for i := range sizes {
-		if s.namespace.ID != nil {
-			namespaces[i] = s.namespace
-		} else {
-			// generate a random namespace for the blob
-			namespace := make([]byte, ns.NamespaceVersionZeroIDSize)
-			_, err := rand.Read(namespace)
-			if err != nil {
-				return Operation{}, fmt.Errorf("generating random namespace: %w", err)
-			}
-			namespaces[i] = ns.MustNewV0(namespace)
+		namespace, err := s.nextNamespace(rand)
+		if err != nil {
+			return Operation{}, fmt.Errorf("generating random namespace: %w", err)
+		}
+		namespaces[i] = namespace
+		if s.squareSize == 0 {
+			sizes[i] = s.sizes.Rand(rand)
 		}
-		sizes[i] = s.sizes.Rand(rand)
+	}
+	if s.byteBudget > 0 {
+		sizes, namespaces = s.fitBudget(sizes, namespaces)
 	}
 	// generate the blobs
 	blobs := blobfactory.RandBlobsWithNamespace(namespaces, sizes)
@@ -89,11 +169,63 @@ func (s *BlobSequence) Next(_ context.Context, _ grpc.ClientConn, rand *rand.Ran
 	if err != nil {
 		return Operation{}, err
 	}
-	return Operation{
+	op := Operation{
 		Msgs:     []types.Msg{msg},
 		Blobs:    blobs,
 		GasLimit: estimateGas(sizes, s.useFeegrant),
-	}, nil
+	}
+	if err := s.gas.apply(&op); err != nil {
+		return Operation{}, err
+	}
+	return op, nil
+}
+
+// fitBudget cuts the blobs short so that they don't exceed the remaining byte
+// budget and records them as spent.
+func (s *BlobSequence) fitBudget(sizes []int, namespaces []ns.Namespace) ([]int, []ns.Namespace) {
+	remaining := s.byteBudget - s.spent
+	for i, size := range sizes {
+		if size >= remaining {
+			sizes[i] = remaining
+			sizes, namespaces = sizes[:i+1], namespaces[:i+1]
+			break
+		}
+		remaining -= size
+	}
+	for _, size := range sizes {
+		s.spent += size
+	}
+	return sizes, namespaces
+}
+
+// nextNamespace returns the fixed namespace if set. Otherwise it draws a
+// namespace from the pool with the hot probability or generates a random one.
+func (s *BlobSequence) nextNamespace(rand *rand.Rand) (ns.Namespace, error) {
+	switch {
+	case s.namespace.ID != nil:
+		return s.namespace, nil
+	case len(s.namespaces) > 0 && s.isHot(rand):
+		return s.namespaces[rand.Intn(len(s.namespaces))], nil
+	default:
+		return randomNamespace(rand)
+	}
+}
+
+// isHot returns true if the namespace should be drawn from the pool. The random
+// source is only used if the outcome is uncertain.
+func (s *BlobSequence) isHot(rand *rand.Rand) bool {
+	if s.hotProbability >= 1 {
+		return true
+	}
+	return rand.Float64() < s.hotProbability
+}
+
+func randomNamespace(rand *rand.Rand) (ns.Namespace, error) {
+	namespace := make([]byte, ns.NamespaceVersionZeroIDSize)
+	if _, err := rand.Read(namespace); err != nil {
+		return ns.Namespace{}, err
+	}
+	return ns.MustNewV0(namespace), nil
 }
 
 type Range struct {
@@ -0,0 +1,52 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	ns "github.com/celestiaorg/go-square/namespace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobSequenceByteBudget(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seq := NewBlobSequence(NewRange(100, 300), NewRange(1, 4)).WithByteBudget(1000)
+	clone := seq.Clone(1)[0]
+	clone.Init(context.Background(), nil, testAllocator, r, false)
+
+	var total, pfbs int
+	for {
+		op, err := clone.Next(context.Background(), nil, r)
+		if errors.Is(err, ErrEndOfSequence) {
+			break
+		}
+		require.NoError(t, err)
+		require.NotEmpty(t, op.Blobs)
+		for _, blob := range op.Blobs {
+			require.Positive(t, len(blob.Data))
+			total += len(blob.Data)
+		}
+		pfbs++
+	}
+	// the last PFB is cut short so that the budget is spent exactly
+	require.Equal(t, 1000, total)
+	require.Greater(t, pfbs, 1)
+}
+
+func TestBlobSequenceFitBudget(t *testing.T) {
+	seq := NewBlobSequence(NewRange(1, 1), NewRange(1, 1)).WithByteBudget(250)
+	namespaces := make([]ns.Namespace, 3)
+
+	sizes, namespaces := seq.fitBudget([]int{100, 100, 100}, namespaces)
+	require.Equal(t, []int{100, 100, 50}, sizes)
+	require.Len(t, namespaces, 3)
+	require.Equal(t, 250, seq.spent)
+
+	// a blob that fits the remaining budget exactly ends the PFB
+	seq.spent = 100
+	sizes, namespaces = seq.fitBudget([]int{150, 100}, make([]ns.Namespace, 2))
+	require.Equal(t, []int{150}, sizes)
+	require.Len(t, namespaces, 1)
+}
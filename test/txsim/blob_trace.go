@@ -0,0 +1,187 @@
+package txsim
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/v2/test/util/blobfactory"
+	blob "github.com/celestiaorg/celestia-app/v2/x/blob/types"
+	ns "github.com/celestiaorg/go-square/namespace"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var _ Sequence = &BlobTraceSequence{}
+
+// TraceMode dictates the order in which the rows of a blob trace are replayed.
+type TraceMode string
+
+const (
+	// CycleTraceMode replays the rows in order, starting again from the first
+	// row once the trace is exhausted.
+	CycleTraceMode TraceMode = "cycle"
+	// SampleTraceMode draws each row uniformly at random from the trace.
+	SampleTraceMode TraceMode = "sample"
+)
+
+// ParseTraceMode returns the trace mode with the given name.
+func ParseTraceMode(mode string) (TraceMode, error) {
+	switch TraceMode(mode) {
+	case CycleTraceMode, SampleTraceMode:
+		return TraceMode(mode), nil
+	default:
+		return "", fmt.Errorf("unknown trace mode %q, expected %q or %q", mode, CycleTraceMode, SampleTraceMode)
+	}
+}
+
+// BlobTraceRow is a single blob of a captured trace.
+type BlobTraceRow struct {
+	Namespace ns.Namespace
+	Size      int
+}
+
+// LoadBlobTrace reads a CSV file of (namespace, size) rows. The namespace is
+// hex encoded and is either the full namespace or the ID of a version zero
+// namespace. A header row starting with "namespace" is skipped. Rows with a
+// malformed or reserved namespace, or a size that is not positive or larger
+// than the default maximum block size, are rejected.
+func LoadBlobTrace(path string) ([]BlobTraceRow, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening blob trace: %w", err)
+	}
+	defer file.Close()
+	return parseBlobTrace(file)
+}
+
+func parseBlobTrace(r io.Reader) ([]BlobTraceRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	rows := make([]BlobTraceRow, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading blob trace: %w", err)
+		}
+		if line == 1 && strings.EqualFold(record[0], "namespace") {
+			continue
+		}
+		row, err := parseBlobTraceRow(record)
+		if err != nil {
+			return nil, fmt.Errorf("blob trace line %d: %w", line, err)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("blob trace is empty")
+	}
+	return rows, nil
+}
+
+func parseBlobTraceRow(record []string) (BlobTraceRow, error) {
+	bz, err := hex.DecodeString(record[0])
+	if err != nil {
+		return BlobTraceRow{}, fmt.Errorf("invalid namespace %q: %w", record[0], err)
+	}
+
+	var namespace ns.Namespace
+	switch len(bz) {
+	case ns.NamespaceVersionZeroIDSize:
+		namespace, err = ns.NewV0(bz)
+	case ns.NamespaceSize:
+		namespace, err = ns.From(bz)
+	default:
+		err = fmt.Errorf("expected %d or %d bytes, got %d", ns.NamespaceVersionZeroIDSize, ns.NamespaceSize, len(bz))
+	}
+	if err != nil {
+		return BlobTraceRow{}, fmt.Errorf("invalid namespace %q: %w", record[0], err)
+	}
+	if err := blob.ValidateBlobNamespace(namespace); err != nil {
+		return BlobTraceRow{}, fmt.Errorf("invalid namespace %q: %w", record[0], err)
+	}
+
+	size, err := strconv.Atoi(record[1])
+	if err != nil {
+		return BlobTraceRow{}, fmt.Errorf("invalid size %q: %w", record[1], err)
+	}
+	if size < 1 || size > appconsts.DefaultMaxBytes {
+		return BlobTraceRow{}, fmt.Errorf("size %d must be between 1 and %d", size, appconsts.DefaultMaxBytes)
+	}
+	return BlobTraceRow{Namespace: namespace, Size: size}, nil
+}
+
+// BlobTraceSequence replays the namespaces and sizes of a captured blob trace.
+// Each PFB contains a single blob with random data matching the next row of
+// the trace. This reproduces the namespace and size distribution of real
+// networks more faithfully than the BlobSequence.
+type BlobTraceSequence struct {
+	rows []BlobTraceRow
+	mode TraceMode
+	// index is the next row to be replayed in cycle mode
+	index int
+
+	account     types.AccAddress
+	useFeegrant bool
+}
+
+func NewBlobTraceSequence(rows []BlobTraceRow, mode TraceMode) *BlobTraceSequence {
+	return &BlobTraceSequence{
+		rows: rows,
+		mode: mode,
+	}
+}
+
+func (s *BlobTraceSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewBlobTraceSequence(s.rows, s.mode)
+	}
+	return sequenceGroup
+}
+
+func (s *BlobTraceSequence) Init(_ context.Context, _ grpc.ClientConn, allocateAccounts AccountAllocator, _ *rand.Rand, useFeegrant bool) {
+	s.useFeegrant = useFeegrant
+	funds := fundsForGas
+	if useFeegrant {
+		funds = 1
+	}
+	s.account = allocateAccounts(1, funds)[0]
+}
+
+func (s *BlobTraceSequence) Next(_ context.Context, _ grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	row := s.nextRow(rand)
+	blobs := blobfactory.RandBlobsWithNamespace([]ns.Namespace{row.Namespace}, []int{row.Size})
+	msg, err := blob.NewMsgPayForBlobs(s.account.String(), appconsts.LatestVersion, blobs...)
+	if err != nil {
+		return Operation{}, err
+	}
+	return Operation{
+		Msgs:     []types.Msg{msg},
+		Blobs:    blobs,
+		GasLimit: estimateGas([]int{row.Size}, s.useFeegrant),
+	}, nil
+}
+
+// nextRow returns the next row of the trace according to the mode.
+func (s *BlobTraceSequence) nextRow(rand *rand.Rand) BlobTraceRow {
+	if s.mode == SampleTraceMode {
+		return s.rows[rand.Intn(len(s.rows))]
+	}
+	row := s.rows[s.index%len(s.rows)]
+	s.index++
+	return row
+}
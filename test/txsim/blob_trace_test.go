@@ -0,0 +1,109 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	blob "github.com/celestiaorg/celestia-app/v2/x/blob/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlobTrace(t *testing.T) {
+	id := strings.Repeat("01", 10)
+	full := "00" + strings.Repeat("00", 18) + strings.Repeat("02", 10)
+
+	testCases := []struct {
+		name   string
+		trace  string
+		sizes  []int
+		expErr string
+	}{
+		{
+			name:  "header and both namespace formats",
+			trace: fmt.Sprintf("namespace,size\n%s,100\n%s, 2000\n", id, full),
+			sizes: []int{100, 2000},
+		},
+		{
+			name:   "empty",
+			trace:  "namespace,size\n",
+			expErr: "blob trace is empty",
+		},
+		{
+			name:   "missing size",
+			trace:  id + "\n",
+			expErr: "wrong number of fields",
+		},
+		{
+			name:   "invalid hex",
+			trace:  "xyz,100\n",
+			expErr: "line 1: invalid namespace",
+		},
+		{
+			name:   "wrong namespace length",
+			trace:  "0102,100\n",
+			expErr: "expected 10 or 29 bytes",
+		},
+		{
+			name:   "reserved namespace",
+			trace:  strings.Repeat("00", 10) + ",100\n",
+			expErr: blob.ErrReservedNamespace.Error(),
+		},
+		{
+			name:   "zero size",
+			trace:  id + ",0\n",
+			expErr: "size 0 must be between",
+		},
+		{
+			name:   "oversized blob",
+			trace:  fmt.Sprintf("%s,100\n%s,%d\n", id, id, appconsts.DefaultMaxBytes+1),
+			expErr: "line 2: size",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows, err := parseBlobTrace(strings.NewReader(tc.trace))
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rows, len(tc.sizes))
+			for i, size := range tc.sizes {
+				require.Equal(t, size, rows[i].Size)
+			}
+		})
+	}
+}
+
+func TestBlobTraceSequence(t *testing.T) {
+	rows, err := parseBlobTrace(strings.NewReader(fmt.Sprintf("%s,100\n%s,200\n", strings.Repeat("01", 10), strings.Repeat("02", 10))))
+	require.NoError(t, err)
+
+	allocate := func(n, _ int) []types.AccAddress {
+		return []types.AccAddress{make([]byte, 20)}
+	}
+	sequence := NewBlobTraceSequence(rows, CycleTraceMode)
+	sequence.Init(context.Background(), nil, allocate, rand.New(rand.NewSource(1)), false)
+
+	// the rows are replayed in order and wrap around
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 4; i++ {
+		op, err := sequence.Next(context.Background(), nil, r)
+		require.NoError(t, err)
+		require.Len(t, op.Blobs, 1)
+		require.Len(t, op.Blobs[0].Data, rows[i%len(rows)].Size)
+		require.Equal(t, rows[i%len(rows)].Namespace.ID, op.Blobs[0].NamespaceId)
+	}
+
+	_, err = ParseTraceMode("shuffle")
+	require.Error(t, err)
+	mode, err := ParseTraceMode("sample")
+	require.NoError(t, err)
+	require.Equal(t, SampleTraceMode, mode)
+}
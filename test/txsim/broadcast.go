@@ -0,0 +1,64 @@
+package txsim
+
+import (
+	"fmt"
+
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// BroadcastMode is the mode in which the transactions of the sequences are
+// broadcast. Regardless of the mode, a transaction is only complete, and its
+// latency recorded, once it has been confirmed as committed.
+type BroadcastMode string
+
+const (
+	// SyncBroadcastMode waits for each transaction to pass CheckTx before
+	// polling for its confirmation. This is the default.
+	SyncBroadcastMode BroadcastMode = "sync"
+	// AsyncBroadcastMode returns as soon as the transaction has been handed to
+	// the node, which maximizes throughput. Transactions rejected by the mempool
+	// are never confirmed and are only noticed once the submit timeout elapses
+	// so it should be combined with Options.WithSubmitTimeout. As a rejected
+	// transaction leaves a gap in the account's sequence, the account's later
+	// transactions are likely to be rejected too.
+	AsyncBroadcastMode BroadcastMode = "async"
+	// BlockBroadcastMode waits for each transaction to be committed before
+	// returning, which orders the transactions of an account strictly at the
+	// cost of one transaction per account per block. The wait is bounded by
+	// the node's timeout_broadcast_tx_commit.
+	BlockBroadcastMode BroadcastMode = "block"
+)
+
+// ParseBroadcastMode returns the broadcast mode with the given name.
+func ParseBroadcastMode(mode string) (BroadcastMode, error) {
+	switch BroadcastMode(mode) {
+	case SyncBroadcastMode, AsyncBroadcastMode, BlockBroadcastMode:
+		return BroadcastMode(mode), nil
+	default:
+		return "", fmt.Errorf("unknown broadcast mode %q, expected %q, %q or %q", mode, SyncBroadcastMode, AsyncBroadcastMode, BlockBroadcastMode)
+	}
+}
+
+// proto returns the broadcast mode of the tx service.
+func (m BroadcastMode) proto() sdktx.BroadcastMode {
+	switch m {
+	case AsyncBroadcastMode:
+		return sdktx.BroadcastMode_BROADCAST_MODE_ASYNC
+	case BlockBroadcastMode:
+		return sdktx.BroadcastMode_BROADCAST_MODE_BLOCK
+	default:
+		return sdktx.BroadcastMode_BROADCAST_MODE_SYNC
+	}
+}
+
+// setBroadcastMode sets the mode in which the subaccounts broadcast their
+// transactions.
+func (am *AccountManager) setBroadcastMode(mode BroadcastMode) error {
+	if _, err := ParseBroadcastMode(string(mode)); err != nil {
+		return err
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.broadcastMode = mode
+	return nil
+}
@@ -0,0 +1,38 @@
+package txsim
+
+import (
+	"testing"
+
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBroadcastMode(t *testing.T) {
+	testCases := []struct {
+		mode    string
+		want    sdktx.BroadcastMode
+		wantErr bool
+	}{
+		{mode: "sync", want: sdktx.BroadcastMode_BROADCAST_MODE_SYNC},
+		{mode: "async", want: sdktx.BroadcastMode_BROADCAST_MODE_ASYNC},
+		{mode: "block", want: sdktx.BroadcastMode_BROADCAST_MODE_BLOCK},
+		{mode: "commit", wantErr: true},
+		{mode: "", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.mode, func(t *testing.T) {
+			mode, err := ParseBroadcastMode(tc.mode)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, mode.proto())
+		})
+	}
+
+	am := &AccountManager{}
+	require.Error(t, am.setBroadcastMode("commit"))
+	require.NoError(t, am.setBroadcastMode(AsyncBroadcastMode))
+	require.Equal(t, AsyncBroadcastMode, am.broadcastMode)
+}
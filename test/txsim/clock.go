@@ -0,0 +1,24 @@
+package txsim
+
+import "time"
+
+// Clock tells the current time. The commit latency of transactions is measured
+// with it so that tests can control the passage of time.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the wall clock. It is the default Clock.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// setClock sets the clock with which the commit latency of transactions is
+// measured.
+func (am *AccountManager) setClock(clock Clock) {
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.clock = clock
+}
@@ -0,0 +1,58 @@
+package txsim
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// manualClock is a Clock that only moves when advanced.
+type manualClock struct {
+	mtx sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+func (c *manualClock) advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// advancingSubmitter advances the clock by an increasing step on each
+// submission as if each took longer to commit than the last.
+type advancingSubmitter struct {
+	clock *manualClock
+	step  time.Duration
+	calls int
+}
+
+func (s *advancingSubmitter) Submit(_ context.Context, _ Operation) error {
+	s.calls++
+	s.clock.advance(time.Duration(s.calls) * s.step)
+	return nil
+}
+
+func TestClockLatency(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	submitter := &advancingSubmitter{clock: clock, step: 100 * time.Millisecond}
+	opts := DefaultOptions().SuppressLogs().WithClock(clock)
+
+	result, err := RunSequences(context.Background(), submitter, opts, &countingSequence{length: 4})
+	require.NoError(t, err)
+	require.Equal(t, 4, result.Committed)
+	require.Equal(t, LatencySummary{
+		Min:  100 * time.Millisecond,
+		Max:  400 * time.Millisecond,
+		Mean: 250 * time.Millisecond,
+		P99:  400 * time.Millisecond,
+	}, result.Latency)
+}
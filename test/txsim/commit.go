@@ -0,0 +1,75 @@
+package txsim
+
+import (
+	"encoding/hex"
+
+	"github.com/rs/zerolog/log"
+)
+
+// commitQueueSize is the number of commits that can be queued for the OnCommit
+// callback before the sequences wait for it to catch up.
+const commitQueueSize = 1024
+
+// OnCommitFunc is called with the id of the sequence, the hash and the height
+// of each committed transaction.
+type OnCommitFunc func(seqID int, txHash []byte, height int64)
+
+type committedTx struct {
+	seqID  int
+	txHash []byte
+	height int64
+}
+
+// commitNotifier invokes the OnCommit callback from a single worker so that the
+// callback doesn't block the sequences. The callbacks are invoked in the order
+// that the transactions were committed. A nil notifier does nothing.
+type commitNotifier struct {
+	onCommit OnCommitFunc
+	commits  chan committedTx
+	done     chan struct{}
+}
+
+// newCommitNotifier starts the worker that invokes onCommit. It returns nil if
+// onCommit is nil.
+func newCommitNotifier(onCommit OnCommitFunc) *commitNotifier {
+	if onCommit == nil {
+		return nil
+	}
+	n := &commitNotifier{
+		onCommit: onCommit,
+		commits:  make(chan committedTx, commitQueueSize),
+		done:     make(chan struct{}),
+	}
+	go n.run()
+	return n
+}
+
+func (n *commitNotifier) run() {
+	defer close(n.done)
+	for commit := range n.commits {
+		n.onCommit(commit.seqID, commit.txHash, commit.height)
+	}
+}
+
+// notify queues the committed transaction for the callback.
+func (n *commitNotifier) notify(seqID int, txHash string, height int64) {
+	if n == nil {
+		return
+	}
+	hash, err := hex.DecodeString(txHash)
+	if err != nil {
+		log.Error().Err(err).Str("tx hash", txHash).Msg("failed to decode tx hash")
+		return
+	}
+	n.commits <- committedTx{seqID: seqID, txHash: hash, height: height}
+}
+
+// close waits for the queued commits to be passed to the callback. notify must
+// not be called afterwards.
+func (n *commitNotifier) close() {
+	if n == nil {
+		return
+	}
+	close(n.commits)
+	<-n.done
+}
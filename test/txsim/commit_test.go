@@ -0,0 +1,36 @@
+package txsim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitNotifier(t *testing.T) {
+	var nilNotifier *commitNotifier
+	nilNotifier.notify(0, "AB", 1)
+	nilNotifier.close()
+	require.Nil(t, newCommitNotifier(nil))
+
+	var heights []int64
+	release := make(chan struct{})
+	notifier := newCommitNotifier(func(seqID int, txHash []byte, height int64) {
+		<-release
+		assert.Equal(t, 1, seqID)
+		assert.Equal(t, []byte{0xab, 0xcd}, txHash)
+		heights = append(heights, height)
+	})
+
+	// notifying doesn't wait for the callback
+	for height := int64(1); height <= 10; height++ {
+		notifier.notify(1, "ABCD", height)
+	}
+	// malformed hashes are skipped
+	notifier.notify(1, "not hex", 11)
+	close(release)
+
+	// closing waits for the queued commits in the order they were notified
+	notifier.close()
+	require.Equal(t, []int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, heights)
+}
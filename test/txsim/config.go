@@ -0,0 +1,318 @@
+package txsim
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// config is the structure of a txsim YAML configuration file.
+type config struct {
+	Seed        int64          `yaml:"seed"`
+	PollTime    time.Duration  `yaml:"poll_time"`
+	Endpoints   []string       `yaml:"endpoints"`
+	Master      string         `yaml:"master"`
+	ChainID     string         `yaml:"chain_id"`
+	UseFeegrant bool           `yaml:"feegrant"`
+	Sequences   []SequenceSpec `yaml:"sequences"`
+}
+
+// SequenceSpec describes a group of sequences in a configuration file. Only the
+// parameters relevant to the type are read. Count is the number of clones of the
+// sequence and defaults to one.
+type SequenceSpec struct {
+	Type  string `yaml:"type"`
+	Count int    `yaml:"count"`
+
+	// blob parameters
+	BlobSizes   string `yaml:"blob_sizes"`
+	BlobsPerPFB string `yaml:"blobs_per_pfb"`
+	Namespaces  int    `yaml:"namespaces"`
+	// HotProbability is the probability that a blob's namespace is drawn
+	// from the pool of namespaces. If unset, the pool is always used.
+	HotProbability float64 `yaml:"hot_probability"`
+	// SquareSize, if set, replaces the blob sizes with sizes that fill a
+	// square of this size
+	SquareSize int `yaml:"square_size"`
+	// ByteBudget, if set, ends each blob sequence once its blobs total this
+	// many bytes
+	ByteBudget int `yaml:"byte_budget"`
+
+	// blob_trace parameters. Trace is the path to a CSV file of (namespace,
+	// size) rows and TraceMode is either cycle, the default, or sample.
+	Trace     string `yaml:"trace"`
+	TraceMode string `yaml:"trace_mode"`
+
+	// Invalid, if set, lists the mutations with which the operations of the
+	// sequence are invalidated. InvalidRate is the fraction of operations that
+	// are invalidated and defaults to all of them.
+	Invalid     []string `yaml:"invalid"`
+	InvalidRate float64  `yaml:"invalid_rate"`
+
+	// GasPriceMultiple, if set, prices the operations of the sequence at this
+	// multiple of the global minimum gas price, which is queried every
+	// GasPriceRefresh, defaulting to DefaultGasPriceRefreshInterval.
+	GasPriceMultiple float64       `yaml:"gas_price_multiple"`
+	GasPriceRefresh  time.Duration `yaml:"gas_price_refresh"`
+
+	// MemoLength, if set, attaches a random memo of this many characters to
+	// every transaction of the sequence
+	MemoLength int `yaml:"memo_length"`
+
+	// GasLimit and GasPrice, if set, pin the gas of every transaction of a
+	// blob or send sequence instead of estimating it
+	GasLimit uint64  `yaml:"gas_limit"`
+	GasPrice float64 `yaml:"gas_price"`
+
+	// send, multisend, multisigner and vesting parameters. For multisend,
+	// accounts is the number of outputs and amount is the maximum amount sent
+	// to each output. For multisigner, accounts is the number of signers of
+	// each transaction and amount is the amount each of them sends.
+	// For vesting, accounts is the number of vesting accounts created and
+	// amount is the maximum amount each vests.
+	Accounts   int `yaml:"accounts"`
+	Amount     int `yaml:"amount"`
+	Iterations int `yaml:"iterations"`
+
+	// stake parameters
+	InitialStake int `yaml:"initial_stake"`
+
+	// staking parameters
+	Delegators int `yaml:"delegators"`
+	Balance    int `yaml:"balance"`
+
+	// gov parameters
+	Voters    int `yaml:"voters"`
+	Proposals int `yaml:"proposals"`
+
+	// vesting parameters. VestingPeriod is the maximum time over which each
+	// account vests.
+	VestingPeriod time.Duration `yaml:"vesting_period"`
+
+	sequences []Sequence
+}
+
+// Sequences returns the sequences constructed from the spec.
+func (s SequenceSpec) Sequences() []Sequence {
+	return s.sequences
+}
+
+// LoadOptions parses the YAML configuration file at path. It returns the options
+// along with a spec for each group of sequences. The endpoints in the file are
+// added to the options so Run can be called with an empty grpc endpoint. For
+// example:
+//
+//	seed: 1234
+//	poll_time: 1s
+//	endpoints: ["localhost:9090"]
+//	sequences:
+//	  - type: blob
+//	    count: 5
+//	    blob_sizes: 100-1000
+//	    blobs_per_pfb: 1-3
+//	  - type: send
+//	    accounts: 2
+//	    amount: 1000
+//	    iterations: 100
+func LoadOptions(path string) (*Options, []SequenceSpec, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var cfg config
+	if err := yaml.UnmarshalStrict(bz, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("parsing config: %w", err)
+	}
+
+	opts := &Options{}
+	opts.WithSeed(cfg.Seed).
+		WithPollTime(cfg.PollTime).
+		WithEndpoints(cfg.Endpoints...).
+		SpecifyMasterAccount(cfg.Master).
+		WithChainID(cfg.ChainID)
+	if cfg.UseFeegrant {
+		opts.UseFeeGrant()
+	}
+	opts.Fill()
+
+	for i := range cfg.Sequences {
+		if err := cfg.Sequences[i].build(); err != nil {
+			return nil, nil, fmt.Errorf("sequence %d: %w", i, err)
+		}
+	}
+
+	return opts, cfg.Sequences, nil
+}
+
+// build constructs the sequences described by the spec.
+func (s *SequenceSpec) build() error {
+	if s.Count == 0 {
+		s.Count = 1
+	}
+	if s.Count < 0 {
+		return fmt.Errorf("count must be positive, got %d", s.Count)
+	}
+
+	if s.GasLimit > 0 && s.Type != "blob" && s.Type != "send" {
+		return fmt.Errorf("gas limit is not supported by %s sequences", s.Type)
+	}
+	if s.GasPrice > 0 && s.GasLimit == 0 {
+		return errors.New("gas price requires a gas limit")
+	}
+	gas := fixedGas{limit: s.GasLimit, price: s.GasPrice}
+	if err := gas.validate(); err != nil {
+		return err
+	}
+
+	var sequence Sequence
+	switch s.Type {
+	case "blob":
+		// blob sizes are ignored if the square size is set
+		sizes := Range{}
+		if s.SquareSize == 0 {
+			var err error
+			sizes, err = ParseRange(s.BlobSizes)
+			if err != nil {
+				return fmt.Errorf("invalid blob sizes: %w", err)
+			}
+		}
+		blobsPerPFB, err := ParseRange(s.BlobsPerPFB)
+		if err != nil {
+			return fmt.Errorf("invalid blobs per pfb: %w", err)
+		}
+		blobSequence := NewBlobSequence(sizes, blobsPerPFB).WithNamespacePool(s.Namespaces)
+		if s.HotProbability > 0 {
+			blobSequence.WithHotNamespaces(s.Namespaces, s.HotProbability)
+		}
+		if s.SquareSize > 0 {
+			blobSequence.WithSquareSize(s.SquareSize)
+		}
+		if s.ByteBudget > 0 {
+			blobSequence.WithByteBudget(s.ByteBudget)
+		}
+		sequence = blobSequence.WithGas(s.GasLimit, s.GasPrice)
+	case "blob_trace":
+		rows, err := LoadBlobTrace(s.Trace)
+		if err != nil {
+			return err
+		}
+		mode := CycleTraceMode
+		if s.TraceMode != "" {
+			mode, err = ParseTraceMode(s.TraceMode)
+			if err != nil {
+				return err
+			}
+		}
+		sequence = NewBlobTraceSequence(rows, mode)
+	case "send":
+		if s.Accounts < 1 || s.Amount < 1 || s.Iterations < 1 {
+			return errors.New("send requires positive accounts, amount and iterations")
+		}
+		if s.GasLimit > 0 && s.GasLimit < SendGasLimit {
+			return fmt.Errorf("gas limit %d is below the send gas limit %d", s.GasLimit, SendGasLimit)
+		}
+		sequence = NewSendSequence(s.Accounts, s.Amount, s.Iterations).WithGas(s.GasLimit, s.GasPrice)
+	case "multisend":
+		if s.Accounts < 1 || s.Amount < 1 || s.Iterations < 1 {
+			return errors.New("multisend requires positive accounts, amount and iterations")
+		}
+		sequence = NewMultiSendSequence(s.Accounts, s.Amount, s.Iterations)
+	case "multisigner":
+		if s.Accounts < 2 || s.Amount < 1 || s.Iterations < 1 {
+			return errors.New("multisigner requires at least 2 accounts and positive amount and iterations")
+		}
+		sequence = NewMultiSignerSequence(s.Accounts, s.Amount, s.Iterations)
+	case "stake":
+		if s.InitialStake < 1 {
+			return errors.New("stake requires a positive initial stake")
+		}
+		sequence = NewStakeSequence(s.InitialStake)
+	case "staking":
+		if s.Delegators < 1 || s.Balance < 1 {
+			return errors.New("staking requires positive delegators and balance")
+		}
+		sequence = NewStakingSequence(s.Delegators, s.Balance)
+	case "gov":
+		if s.Voters < 1 || s.Proposals < 1 {
+			return errors.New("gov requires positive voters and proposals")
+		}
+		sequence = NewGovSequence(s.Voters, s.Proposals)
+	case "vesting":
+		if s.Accounts < 1 || s.Amount < 1 || s.VestingPeriod < time.Second {
+			return errors.New("vesting requires positive accounts and amount and a vesting period of at least 1s")
+		}
+		sequence = NewVestingSequence(s.Accounts, s.Amount, s.VestingPeriod)
+	default:
+		return fmt.Errorf("unknown sequence type %q", s.Type)
+	}
+
+	if s.GasPriceMultiple != 0 {
+		if s.GasPriceMultiple < 0 || s.GasPriceRefresh < 0 {
+			return fmt.Errorf("gas price multiple and refresh must be positive, got %v and %v", s.GasPriceMultiple, s.GasPriceRefresh)
+		}
+		feeMarket := NewFeeMarketSequence(sequence, s.GasPriceMultiple)
+		if s.GasPriceRefresh > 0 {
+			feeMarket.WithRefreshInterval(s.GasPriceRefresh)
+		}
+		sequence = feeMarket
+	}
+
+	if s.MemoLength != 0 {
+		if s.MemoLength < 0 {
+			return fmt.Errorf("memo length must not be negative, got %d", s.MemoLength)
+		}
+		sequence = NewMemoSequence(sequence, s.MemoLength)
+	}
+
+	if len(s.Invalid) > 0 {
+		if s.InvalidRate < 0 || s.InvalidRate > 1 {
+			return fmt.Errorf("invalid rate must be between 0 and 1, got %v", s.InvalidRate)
+		}
+		mutations := make([]Mutation, len(s.Invalid))
+		for i, name := range s.Invalid {
+			mutation, err := ParseMutation(name)
+			if err != nil {
+				return err
+			}
+			mutations[i] = mutation
+		}
+		invalid := NewInvalidSequence(sequence, mutations...)
+		if s.InvalidRate > 0 {
+			invalid.WithRate(s.InvalidRate)
+		}
+		sequence = invalid
+	}
+
+	s.sequences = sequence.Clone(s.Count)
+	return nil
+}
+
+// ParseRange takes a string expected to be of the form "1-10" and returns the
+// corresponding Range. If only one number is set i.e. "5", the range returned
+// is {5, 5}.
+func ParseRange(r string) (Range, error) {
+	if r == "" {
+		return Range{}, errors.New("range is empty")
+	}
+
+	res := strings.Split(r, "-")
+	n, err := strconv.Atoi(res[0])
+	if err != nil {
+		return Range{}, err
+	}
+	if len(res) == 1 {
+		return NewRange(n, n), nil
+	}
+	m, err := strconv.Atoi(res[1])
+	if err != nil {
+		return Range{}, err
+	}
+
+	return NewRange(n, m), nil
+}
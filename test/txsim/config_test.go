@@ -0,0 +1,278 @@
+package txsim
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOptions(t *testing.T) {
+	testCases := []struct {
+		name      string
+		config    string
+		sequences []int
+		expErr    string
+	}{
+		{
+			name: "all sequence types",
+			config: `
+seed: 1234
+poll_time: 2s
+endpoints: ["localhost:9090", "localhost:9091"]
+master: validator
+feegrant: true
+sequences:
+  - type: blob
+    count: 3
+    blob_sizes: 100-1000
+    blobs_per_pfb: "2"
+    gas_limit: 1000000
+    gas_price: 0.1
+  - type: send
+    accounts: 2
+    amount: 1000
+    iterations: 10
+    gas_limit: 150000
+  - type: stake
+    initial_stake: 1000
+  - type: staking
+    count: 2
+    delegators: 2
+    balance: 1000
+  - type: gov
+    voters: 3
+    proposals: 1
+  - type: multisend
+    accounts: 5
+    amount: 100
+    iterations: 10
+    gas_price_multiple: 1.5
+    gas_price_refresh: 30s
+    memo_length: 64
+  - type: vesting
+    count: 2
+    accounts: 5
+    amount: 1000
+    vesting_period: 1h
+  - type: send
+    count: 2
+    accounts: 2
+    amount: 1000
+    iterations: 10
+    invalid: [underpaid_fee, bad_signature]
+    invalid_rate: 0.1
+  - type: multisigner
+    accounts: 3
+    amount: 100
+    iterations: 10
+`,
+			sequences: []int{3, 1, 1, 2, 1, 1, 2, 2, 1},
+		},
+		{
+			name: "unknown sequence type",
+			config: `
+sequences:
+  - type: ibc
+`,
+			expErr: `unknown sequence type "ibc"`,
+		},
+		{
+			name: "invalid blob sizes",
+			config: `
+sequences:
+  - type: blob
+    blobs_per_pfb: "1"
+`,
+			expErr: "invalid blob sizes",
+		},
+		{
+			name: "multisend without outputs",
+			config: `
+sequences:
+  - type: multisend
+    amount: 100
+    iterations: 10
+`,
+			expErr: "multisend requires positive accounts, amount and iterations",
+		},
+		{
+			name: "multisigner with a single signer",
+			config: `
+sequences:
+  - type: multisigner
+    accounts: 1
+    amount: 100
+    iterations: 10
+`,
+			expErr: "multisigner requires at least 2 accounts and positive amount and iterations",
+		},
+		{
+			name: "send without accounts",
+			config: `
+sequences:
+  - type: send
+    accounts: 0
+    amount: 1000
+    iterations: 10
+`,
+			expErr: "send requires positive accounts, amount and iterations",
+		},
+		{
+			name: "stake without an initial stake",
+			config: `
+sequences:
+  - type: stake
+`,
+			expErr: "stake requires a positive initial stake",
+		},
+		{
+			name: "staking without delegators",
+			config: `
+sequences:
+  - type: staking
+    delegators: 0
+    balance: 1000
+`,
+			expErr: "staking requires positive delegators and balance",
+		},
+		{
+			name: "gov without voters",
+			config: `
+sequences:
+  - type: gov
+    voters: 0
+    proposals: 1
+`,
+			expErr: "gov requires positive voters and proposals",
+		},
+		{
+			name: "vesting without a period",
+			config: `
+sequences:
+  - type: vesting
+    accounts: 5
+    amount: 1000
+`,
+			expErr: "vesting requires positive accounts and amount and a vesting period of at least 1s",
+		},
+		{
+			name: "unknown mutation",
+			config: `
+sequences:
+  - type: send
+    accounts: 2
+    amount: 1000
+    iterations: 10
+    invalid: [bad_memo]
+`,
+			expErr: `unknown mutation "bad_memo"`,
+		},
+		{
+			name: "negative gas price multiple",
+			config: `
+sequences:
+  - type: send
+    accounts: 2
+    amount: 1000
+    iterations: 10
+    gas_price_multiple: -1
+`,
+			expErr: "gas price multiple and refresh must be positive",
+		},
+		{
+			name: "negative memo length",
+			config: `
+sequences:
+  - type: send
+    accounts: 2
+    amount: 1000
+    iterations: 10
+    memo_length: -1
+`,
+			expErr: "memo length must not be negative",
+		},
+		{
+			name: "send gas limit too low",
+			config: `
+sequences:
+  - type: send
+    accounts: 2
+    amount: 1000
+    iterations: 10
+    gas_limit: 1000
+`,
+			expErr: "gas limit 1000 is below the send gas limit",
+		},
+		{
+			name: "gas price below minimum",
+			config: `
+sequences:
+  - type: blob
+    blob_sizes: 100
+    blobs_per_pfb: "1"
+    gas_limit: 1000000
+    gas_price: 0.0001
+`,
+			expErr: "is below the minimum gas price",
+		},
+		{
+			name: "gas limit on unsupported sequence",
+			config: `
+sequences:
+  - type: stake
+    initial_stake: 1000
+    gas_limit: 1000000
+`,
+			expErr: "gas limit is not supported by stake sequences",
+		},
+		{
+			name: "unknown field",
+			config: `
+sead: 1234
+`,
+			expErr: "field sead not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.config), 0o600))
+
+			opts, specs, err := LoadOptions(path)
+			if tc.expErr != "" {
+				require.ErrorContains(t, err, tc.expErr)
+				return
+			}
+			require.NoError(t, err)
+			require.EqualValues(t, 1234, opts.seed)
+			require.Equal(t, 2*time.Second, opts.pollTime)
+			require.Equal(t, []string{"localhost:9090", "localhost:9091"}, opts.endpoints)
+			require.Equal(t, "validator", opts.masterAcc)
+			require.True(t, opts.useFeeGrant)
+			require.Len(t, specs, len(tc.sequences))
+			for i, count := range tc.sequences {
+				require.Len(t, specs[i].Sequences(), count)
+			}
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	r, err := ParseRange("5")
+	require.NoError(t, err)
+	require.Equal(t, NewRange(5, 5), r)
+
+	r, err = ParseRange("1-10")
+	require.NoError(t, err)
+	require.Equal(t, NewRange(1, 10), r)
+
+	_, err = ParseRange("")
+	require.Error(t, err)
+
+	_, err = ParseRange("a-10")
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package txsim
+
+import (
+	"context"
+	"sync"
+)
+
+// SequenceControl lets the caller of Run stop individual sequences while the
+// others continue until the run's context is cancelled. Sequences are
+// identified by their index in the sequences passed to Run, as with the
+// OnCommit callback. It is safe for concurrent use.
+type SequenceControl struct {
+	mtx     sync.Mutex
+	cancels map[int]context.CancelFunc
+	stopped map[int]bool
+}
+
+// NewSequenceControl returns a SequenceControl to pass to
+// Options.WithSequenceControl.
+func NewSequenceControl() *SequenceControl {
+	return &SequenceControl{
+		cancels: make(map[int]context.CancelFunc),
+		stopped: make(map[int]bool),
+	}
+}
+
+// Stop stops the sequence with the given id. Its transaction in flight, if any,
+// is abandoned, or drained if a drain timeout is set, and the sequence
+// terminates with ErrEndOfSequence. A sequence
+// that is stopped before the run starts it never submits any transactions.
+// Stopping a sequence more than once has no effect.
+func (c *SequenceControl) Stop(seqID int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.stopped[seqID] = true
+	if cancel, ok := c.cancels[seqID]; ok {
+		cancel()
+	}
+}
+
+// start returns the context in which the sequence runs, which is cancelled
+// when the sequence is stopped. A nil control returns a child of ctx that is
+// only cancelled by the returned function.
+func (c *SequenceControl) start(ctx context.Context, seqID int) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if c == nil {
+		return ctx, cancel
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.cancels[seqID] = cancel
+	if c.stopped[seqID] {
+		cancel()
+	}
+	return ctx, cancel
+}
+
+// isStopped returns whether the sequence was stopped.
+func (c *SequenceControl) isStopped(seqID int) bool {
+	if c == nil {
+		return false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.stopped[seqID]
+}
@@ -0,0 +1,73 @@
+package txsim
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceControl(t *testing.T) {
+	submit := func(ctx context.Context, _ Operation) (submitResult, error) {
+		if err := sleep(ctx, time.Millisecond); err != nil {
+			return submitResult{}, err
+		}
+		return submitResult{response: &types.TxResponse{}}, nil
+	}
+
+	var (
+		mtx     sync.Mutex
+		commits = make(map[int]int)
+	)
+	committed := func(seqID int) int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return commits[seqID]
+	}
+	control := NewSequenceControl()
+	opts := DefaultOptions().
+		WithSequenceControl(control).
+		WithOnCommit(func(seqID int, _ []byte, _ int64) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			commits[seqID]++
+		})
+	// the last sequence is stopped before it starts
+	control.Stop(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	type result struct {
+		stats []*sequenceStats
+		err   error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		sequences := []Sequence{&countingSequence{length: 1e9}, &countingSequence{length: 1e9}, &countingSequence{length: 1e9}}
+		stats, err := runSequences(ctx, opts, nil, submit, nil, nil, sequences)
+		resultCh <- result{stats: stats, err: err}
+	}()
+
+	require.Eventually(t, func() bool { return committed(0) >= 5 }, 10*time.Second, time.Millisecond)
+	control.Stop(0)
+	// stopping a sequence twice has no effect
+	control.Stop(0)
+
+	// the other sequence continues to run
+	running := committed(1)
+	require.Eventually(t, func() bool { return committed(1) >= running+20 }, 10*time.Second, time.Millisecond)
+	stopped := committed(0)
+	cancel()
+
+	res := <-resultCh
+	require.NoError(t, res.err)
+	require.Equal(t, stopped, committed(0))
+	require.Equal(t, stopped, res.stats[0].committed)
+	require.ErrorIs(t, res.stats[0].lastErr, ErrEndOfSequence)
+	require.ErrorIs(t, res.stats[1].lastErr, context.Canceled)
+	require.ErrorIs(t, res.stats[2].lastErr, ErrEndOfSequence)
+	require.Zero(t, res.stats[2].committed)
+}
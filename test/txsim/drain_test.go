@@ -0,0 +1,79 @@
+package txsim
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainTimeout(t *testing.T) {
+	testCases := []struct {
+		name          string
+		drainTimeout  time.Duration
+		delay         time.Duration
+		wantDrained   int
+		wantAbandoned int
+	}{
+		{
+			name:          "no drain timeout abandons in flight submissions",
+			delay:         time.Second,
+			wantAbandoned: 2,
+		},
+		{
+			name:         "in flight submissions are drained",
+			drainTimeout: 10 * time.Second,
+			delay:        200 * time.Millisecond,
+			wantDrained:  2,
+		},
+		{
+			name:          "submissions exceeding the drain timeout are abandoned",
+			drainTimeout:  50 * time.Millisecond,
+			delay:         10 * time.Second,
+			wantAbandoned: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var submitted atomic.Int64
+			submit := func(ctx context.Context, _ Operation) (submitResult, error) {
+				submitted.Add(1)
+				if err := sleep(ctx, tc.delay); err != nil {
+					return submitResult{}, err
+				}
+				return submitResult{response: &types.TxResponse{}}, nil
+			}
+			opts := DefaultOptions().WithDrainTimeout(tc.drainTimeout)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			type result struct {
+				stats []*sequenceStats
+				err   error
+			}
+			resultCh := make(chan result, 1)
+			go func() {
+				sequences := []Sequence{&countingSequence{length: 1e9}, &countingSequence{length: 1e9}}
+				stats, err := runSequences(ctx, opts, nil, submit, nil, nil, sequences)
+				resultCh <- result{stats: stats, err: err}
+			}()
+
+			// cancel once both sequences have a submission in flight
+			require.Eventually(t, func() bool { return submitted.Load() == 2 }, 10*time.Second, time.Millisecond)
+			cancel()
+
+			res := <-resultCh
+			require.NoError(t, res.err)
+			runResult := newRunResult(res.stats)
+			require.Equal(t, tc.wantDrained, runResult.Drained)
+			require.Equal(t, tc.wantAbandoned, runResult.Abandoned)
+			require.Equal(t, tc.wantDrained, runResult.Committed)
+			// no operations are produced once the run is cancelled
+			require.EqualValues(t, 2, submitted.Load())
+		})
+	}
+}
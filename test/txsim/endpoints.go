@@ -0,0 +1,109 @@
+package txsim
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// endpointBackoff is how long an unreachable endpoint is skipped for before
+// it is tried again.
+const endpointBackoff = 10 * time.Second
+
+type endpoint struct {
+	address        string
+	conn           *grpc.ClientConn
+	unhealthyUntil time.Time
+}
+
+// endpointPool distributes transactions across a set of grpc connections in a
+// round-robin fashion, skipping endpoints that have recently been unreachable.
+// This is thread safe.
+type endpointPool struct {
+	mtx       sync.Mutex
+	endpoints []*endpoint
+	next      int
+}
+
+// dialEndpoints establishes a connection to each of the provided addresses.
+// If a TLS configuration is provided, it is used to secure each connection.
+func dialEndpoints(addresses []string, tlsConfig *tls.Config) (*endpointPool, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("at least one grpc endpoint must be provided")
+	}
+	pool := &endpointPool{endpoints: make([]*endpoint, len(addresses))}
+	for i, address := range addresses {
+		creds, err := transportCredentials(tlsConfig, address)
+		if err != nil {
+			pool.endpoints = pool.endpoints[:i]
+			return nil, errors.Join(err, pool.Close())
+		}
+		conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			pool.endpoints = pool.endpoints[:i]
+			return nil, errors.Join(fmt.Errorf("dialing %s: %w", address, err), pool.Close())
+		}
+		pool.endpoints[i] = &endpoint{address: address, conn: conn}
+	}
+	return pool, nil
+}
+
+// Close closes the connection of each endpoint.
+func (p *endpointPool) Close() error {
+	var errs []error
+	for _, e := range p.endpoints {
+		if err := e.conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing %s: %w", e.address, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// primary returns the connection of the first endpoint which is used for
+// querying state.
+func (p *endpointPool) primary() *grpc.ClientConn {
+	return p.endpoints[0].conn
+}
+
+func (p *endpointPool) size() int {
+	return len(p.endpoints)
+}
+
+// pick returns the next healthy endpoint. If all endpoints are unhealthy, the
+// endpoint whose backoff expires first is returned.
+func (p *endpointPool) pick() *endpoint {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	now := time.Now()
+	var soonest *endpoint
+	for i := 0; i < len(p.endpoints); i++ {
+		e := p.endpoints[(p.next+i)%len(p.endpoints)]
+		if !now.Before(e.unhealthyUntil) {
+			p.next = (p.next + i + 1) % len(p.endpoints)
+			return e
+		}
+		if soonest == nil || e.unhealthyUntil.Before(soonest.unhealthyUntil) {
+			soonest = e
+		}
+	}
+	return soonest
+}
+
+// markUnhealthy skips the endpoint for the backoff window.
+func (p *endpointPool) markUnhealthy(e *endpoint) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	e.unhealthyUntil = time.Now().Add(endpointBackoff)
+}
+
+// isUnreachable returns true if the error indicates that the node could not
+// be reached and therefore never received the transaction.
+func isUnreachable(err error) bool {
+	return status.Code(err) == codes.Unavailable
+}
@@ -0,0 +1,24 @@
+package txsim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
+)
+
+func TestEndpointPoolClose(t *testing.T) {
+	// dialing doesn't block so the endpoints don't need to be reachable
+	pool, err := dialEndpoints([]string{"localhost:9090", "localhost:9091"}, nil)
+	require.NoError(t, err)
+
+	manager := &AccountManager{}
+	manager.setEndpoints(pool)
+	require.NoError(t, manager.Close())
+	for _, e := range pool.endpoints {
+		require.Equal(t, connectivity.Shutdown, e.conn.GetState())
+	}
+
+	// a manager without endpoints has nothing to close
+	require.NoError(t, (&AccountManager{}).Close())
+}
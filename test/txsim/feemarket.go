@@ -0,0 +1,135 @@
+package txsim
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/v2/x/minfee"
+	"github.com/gogo/protobuf/grpc"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	_ Sequence      = &FeeMarketSequence{}
+	_ ResultHandler = &FeeMarketSequence{}
+)
+
+// DefaultGasPriceRefreshInterval is the interval at which the FeeMarketSequence
+// queries the global minimum gas price by default.
+const DefaultGasPriceRefreshInterval = 10 * time.Second
+
+// FeeMarketSequence wraps a sequence and prices its operations at a multiple of
+// the network's global minimum gas price, which is queried from the minfee
+// module and refreshed periodically so that the price adapts as governance
+// changes the minimum. With a multiple below one, the operations are expected to
+// be rejected by the global minimum fee check and their rejections are recorded
+// in the SequenceResult. If the query fails or there is no global minimum,
+// as is the case for app version 1, the multiple is applied to the fallback gas
+// price, which defaults to the default minimum gas price of a node, and a
+// warning is logged. Operations without a gas limit are given DefaultGasLimit.
+//
+// NOTE: the accounts of the inner sequence are funded for the default gas
+// price so large multiples may exhaust them.
+type FeeMarketSequence struct {
+	inner            Sequence
+	multiple         float64
+	refreshInterval  time.Duration
+	fallbackGasPrice float64
+
+	gasPrice    float64
+	lastRefresh time.Time
+}
+
+// NewFeeMarketSequence prices the operations of the inner sequence at multiple
+// times the global minimum gas price.
+func NewFeeMarketSequence(inner Sequence, multiple float64) *FeeMarketSequence {
+	return &FeeMarketSequence{
+		inner:            inner,
+		multiple:         multiple,
+		refreshInterval:  DefaultGasPriceRefreshInterval,
+		fallbackGasPrice: appconsts.DefaultMinGasPrice,
+	}
+}
+
+// WithRefreshInterval sets how often the global minimum gas price is queried.
+func (s *FeeMarketSequence) WithRefreshInterval(interval time.Duration) *FeeMarketSequence {
+	s.refreshInterval = interval
+	return s
+}
+
+// WithFallbackGasPrice sets the gas price to which the multiple is applied if
+// the global minimum gas price is not available.
+func (s *FeeMarketSequence) WithFallbackGasPrice(gasPrice float64) *FeeMarketSequence {
+	s.fallbackGasPrice = gasPrice
+	return s
+}
+
+func (s *FeeMarketSequence) Clone(n int) []Sequence {
+	inner := s.inner.Clone(n)
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewFeeMarketSequence(inner[i], s.multiple).
+			WithRefreshInterval(s.refreshInterval).
+			WithFallbackGasPrice(s.fallbackGasPrice)
+	}
+	return sequenceGroup
+}
+
+func (s *FeeMarketSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, rand *rand.Rand, useFeegrant bool) {
+	s.inner.Init(ctx, querier, allocateAccounts, rand, useFeegrant)
+}
+
+// Next returns the next operation of the inner sequence priced at the multiple
+// of the latest global minimum gas price.
+func (s *FeeMarketSequence) Next(ctx context.Context, querier grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	op, err := s.inner.Next(ctx, querier, rand)
+	if err != nil {
+		return Operation{}, err
+	}
+	if s.lastRefresh.IsZero() || time.Since(s.lastRefresh) >= s.refreshInterval {
+		s.refresh(ctx, querier)
+	}
+
+	if op.GasLimit == 0 {
+		op.GasLimit = DefaultGasLimit
+	}
+	op.GasPrice = s.gasPrice * s.multiple
+	if s.multiple < 1 {
+		op.ExpectRejection = true
+	}
+	return op, nil
+}
+
+// OnResult passes the results to the inner sequence.
+func (s *FeeMarketSequence) OnResult(ctx context.Context, results []OperationResult) {
+	forwardResults(ctx, s.inner, results)
+}
+
+// refresh queries the global minimum gas price, falling back to the fallback
+// gas price if it is not available.
+func (s *FeeMarketSequence) refresh(ctx context.Context, querier grpc.ClientConn) {
+	s.lastRefresh = time.Now()
+	gasPrice := s.fallbackGasPrice
+	resp, err := minfee.NewQueryClient(querier).MinFee(ctx, &minfee.QueryMinFeeRequest{})
+	switch {
+	case err != nil:
+		log.Warn().Err(err).Float64("gas price", gasPrice).Msg("failed to query the global min gas price, using the fallback")
+	case !resp.GlobalMinGasPrice.IsPositive():
+		log.Warn().Float64("gas price", gasPrice).Msg("no global min gas price, using the fallback")
+	default:
+		gasPrice, err = resp.GlobalMinGasPrice.Float64()
+		if err != nil {
+			log.Warn().Err(err).Str("global min gas price", resp.GlobalMinGasPrice.String()).Msg("failed to convert the global min gas price, using the fallback")
+			gasPrice = s.fallbackGasPrice
+		}
+	}
+	if gasPrice != s.gasPrice {
+		log.Info().
+			Float64("min gas price", gasPrice).
+			Float64("gas price", gasPrice*s.multiple).
+			Msg("updated gas price")
+	}
+	s.gasPrice = gasPrice
+}
@@ -0,0 +1,88 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/v2/x/minfee"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// minFeeConn answers minfee queries with a fixed global min gas price or error.
+type minFeeConn struct {
+	gogogrpc.ClientConn
+	globalMinGasPrice sdk.Dec
+	err               error
+	queries           int
+}
+
+func (c *minFeeConn) Invoke(_ context.Context, _ string, _, reply interface{}, _ ...grpc.CallOption) error {
+	c.queries++
+	if c.err != nil {
+		return c.err
+	}
+	reply.(*minfee.QueryMinFeeResponse).GlobalMinGasPrice = c.globalMinGasPrice
+	return nil
+}
+
+func TestFeeMarketSequence(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	t.Run("prices at a multiple of the global min gas price", func(t *testing.T) {
+		conn := &minFeeConn{globalMinGasPrice: sdk.NewDecWithPrec(4, 3)}
+		seq := NewFeeMarketSequence(&countingSequence{length: 10}, 2).WithRefreshInterval(time.Hour)
+		for i := 0; i < 2; i++ {
+			op, err := seq.Next(context.Background(), conn, r)
+			require.NoError(t, err)
+			require.InDelta(t, 0.008, op.GasPrice, 1e-12)
+			require.EqualValues(t, DefaultGasLimit, op.GasLimit)
+			require.False(t, op.ExpectRejection)
+		}
+		// the price is only queried once per refresh interval
+		require.Equal(t, 1, conn.queries)
+	})
+
+	t.Run("adapts to a new global min gas price", func(t *testing.T) {
+		conn := &minFeeConn{globalMinGasPrice: sdk.NewDecWithPrec(4, 3)}
+		seq := NewFeeMarketSequence(&countingSequence{length: 10}, 1).WithRefreshInterval(time.Nanosecond)
+		_, err := seq.Next(context.Background(), conn, r)
+		require.NoError(t, err)
+
+		conn.globalMinGasPrice = sdk.NewDecWithPrec(1, 2)
+		time.Sleep(time.Millisecond)
+		op, err := seq.Next(context.Background(), conn, r)
+		require.NoError(t, err)
+		require.InDelta(t, 0.01, op.GasPrice, 1e-12)
+	})
+
+	t.Run("falls back if the query fails", func(t *testing.T) {
+		conn := &minFeeConn{err: errors.New("unknown service")}
+		seq := NewFeeMarketSequence(&countingSequence{length: 10}, 0.5)
+		op, err := seq.Next(context.Background(), conn, r)
+		require.NoError(t, err)
+		require.InDelta(t, appconsts.DefaultMinGasPrice/2, op.GasPrice, 1e-12)
+		// underpaid operations are expected to be rejected
+		require.True(t, op.ExpectRejection)
+	})
+
+	t.Run("falls back if there is no global minimum", func(t *testing.T) {
+		conn := &minFeeConn{globalMinGasPrice: sdk.ZeroDec()}
+		seq := NewFeeMarketSequence(&countingSequence{length: 10}, 1).WithFallbackGasPrice(0.1)
+		op, err := seq.Next(context.Background(), conn, r)
+		require.NoError(t, err)
+		require.InDelta(t, 0.1, op.GasPrice, 1e-12)
+	})
+
+	t.Run("ends with the inner sequence", func(t *testing.T) {
+		seq := NewFeeMarketSequence(&countingSequence{}, 1)
+		_, err := seq.Next(context.Background(), &minFeeConn{}, r)
+		require.ErrorIs(t, err, ErrEndOfSequence)
+	})
+}
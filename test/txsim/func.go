@@ -0,0 +1,119 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var (
+	_ Sequence      = &FuncSequence{}
+	_ ResultHandler = &FuncSequence{}
+)
+
+// InitFunc initializes a FuncSequence with the accounts allocated to it.
+type InitFunc func(ctx context.Context, querier grpc.ClientConn, accounts []types.AccAddress, rand *rand.Rand) error
+
+// NextFunc returns the next operations of a FuncSequence. It returns
+// ErrEndOfSequence once the sequence has been exhausted.
+type NextFunc func(ctx context.Context, querier grpc.ClientConn, rand *rand.Rand) ([]Operation, error)
+
+// ResultFunc is passed the results of the operations of a FuncSequence.
+type ResultFunc func(ctx context.Context, results []OperationResult)
+
+// FuncSequence adapts a pair of functions to a Sequence so that one-off traffic
+// can be scripted without defining a new type. The accounts requested with
+// WithAccounts are allocated and funded as for any other sequence and passed to
+// the init function, which is optional. Each call to the next function may
+// return several operations, which are submitted one after the other before it
+// is called again. An error returned by the init function is returned by the
+// first call to Next.
+//
+// NOTE: clones share the functions so any state that they capture, such as the
+// accounts, is shared between the clones as well.
+type FuncSequence struct {
+	init        InitFunc
+	next        NextFunc
+	onResult    ResultFunc
+	numAccounts int
+	balance     int
+
+	pending []Operation
+	initErr error
+}
+
+// NewFuncSequence returns a sequence that is initialized by init, if not nil,
+// and whose operations are produced by next.
+func NewFuncSequence(init InitFunc, next NextFunc) *FuncSequence {
+	return &FuncSequence{init: init, next: next}
+}
+
+// WithAccounts allocates n accounts, each funded with balance utia, that are
+// passed to the init function.
+func (s *FuncSequence) WithAccounts(n, balance int) *FuncSequence {
+	s.numAccounts = n
+	s.balance = balance
+	return s
+}
+
+// WithOnResult sets a function that is passed the results of the operations,
+// i.e. to react to the events they emitted. See ResultHandler.
+func (s *FuncSequence) WithOnResult(onResult ResultFunc) *FuncSequence {
+	s.onResult = onResult
+	return s
+}
+
+func (s *FuncSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewFuncSequence(s.init, s.next).
+			WithAccounts(s.numAccounts, s.balance).
+			WithOnResult(s.onResult)
+	}
+	return sequenceGroup
+}
+
+// Init allocates the accounts of the sequence and calls the init function.
+func (s *FuncSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, rand *rand.Rand, _ bool) {
+	var accounts []types.AccAddress
+	if s.numAccounts > 0 {
+		accounts = allocateAccounts(s.numAccounts, s.balance)
+	}
+	if s.init == nil {
+		return
+	}
+	if err := s.init(ctx, querier, accounts, rand); err != nil {
+		s.initErr = fmt.Errorf("initializing func sequence: %w", err)
+	}
+}
+
+// Next returns the next pending operation, calling the next function once all
+// of the operations it previously returned have been submitted.
+func (s *FuncSequence) Next(ctx context.Context, querier grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	if s.initErr != nil {
+		return Operation{}, s.initErr
+	}
+	for len(s.pending) == 0 {
+		if err := ctx.Err(); err != nil {
+			return Operation{}, err
+		}
+		ops, err := s.next(ctx, querier, rand)
+		if err != nil {
+			return Operation{}, err
+		}
+		s.pending = ops
+	}
+	op := s.pending[0]
+	s.pending = s.pending[1:]
+	return op, nil
+}
+
+// OnResult passes the results to the result function, if set.
+func (s *FuncSequence) OnResult(ctx context.Context, results []OperationResult) {
+	if s.onResult != nil {
+		s.onResult(ctx, results)
+	}
+}
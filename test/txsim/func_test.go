@@ -0,0 +1,126 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/grpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuncSequence(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	t.Run("allocates accounts and flattens operations", func(t *testing.T) {
+		var (
+			accounts []types.AccAddress
+			calls    int
+		)
+		seq := NewFuncSequence(
+			func(_ context.Context, _ grpc.ClientConn, allocated []types.AccAddress, _ *rand.Rand) error {
+				accounts = allocated
+				return nil
+			},
+			func(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) ([]Operation, error) {
+				calls++
+				if calls > 2 {
+					return nil, ErrEndOfSequence
+				}
+				return []Operation{{GasLimit: 1}, {GasLimit: 2}}, nil
+			},
+		).WithAccounts(3, 1000)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		require.Len(t, accounts, 3)
+
+		var gasLimits []uint64
+		for {
+			op, err := seq.Next(context.Background(), nil, r)
+			if errors.Is(err, ErrEndOfSequence) {
+				break
+			}
+			require.NoError(t, err)
+			gasLimits = append(gasLimits, op.GasLimit)
+		}
+		require.Equal(t, []uint64{1, 2, 1, 2}, gasLimits)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("init error is returned by next", func(t *testing.T) {
+		initErr := errors.New("init failed")
+		seq := NewFuncSequence(
+			func(_ context.Context, _ grpc.ClientConn, _ []types.AccAddress, _ *rand.Rand) error {
+				return initErr
+			},
+			func(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) ([]Operation, error) {
+				return []Operation{{}}, nil
+			},
+		)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		_, err := seq.Next(context.Background(), nil, r)
+		require.ErrorIs(t, err, initErr)
+	})
+
+	t.Run("clones allocate their own accounts", func(t *testing.T) {
+		allocated := 0
+		allocator := func(n, balance int) []types.AccAddress {
+			require.Equal(t, 1000, balance)
+			allocated += n
+			return testAllocator(n, balance)
+		}
+		seq := NewFuncSequence(nil, func(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) ([]Operation, error) {
+			return nil, ErrEndOfSequence
+		}).WithAccounts(2, 1000)
+		for _, clone := range seq.Clone(3) {
+			clone.Init(context.Background(), nil, allocator, r, false)
+		}
+		require.Equal(t, 6, allocated)
+	})
+}
+
+// printSubmitter prints the amount of each send instead of submitting it.
+type printSubmitter struct{}
+
+func (printSubmitter) Submit(_ context.Context, op Operation) error {
+	fmt.Println(op.Msgs[0].(*bank.MsgSend).Amount)
+	return nil
+}
+
+// ExampleNewFuncSequence scripts a sequence that sends increasing amounts
+// between two accounts without defining a new type.
+func ExampleNewFuncSequence() {
+	var (
+		accounts []types.AccAddress
+		amount   int64
+	)
+	sequence := NewFuncSequence(
+		func(_ context.Context, _ grpc.ClientConn, allocated []types.AccAddress, _ *rand.Rand) error {
+			accounts = allocated
+			return nil
+		},
+		func(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) ([]Operation, error) {
+			if amount >= 3 {
+				return nil, ErrEndOfSequence
+			}
+			amount++
+			send := bank.NewMsgSend(accounts[0], accounts[1], types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, amount)))
+			return []Operation{{Msgs: []types.Msg{send}, GasLimit: SendGasLimit}}, nil
+		},
+	).WithAccounts(2, 1_000_000)
+
+	// Run initializes the sequence with funded accounts. Here, the sequence is
+	// initialized by hand and its operations are printed instead of submitted.
+	sequence.Init(context.Background(), nil, testAllocator, rand.New(rand.NewSource(1)), false)
+	result, err := RunSequences(context.Background(), printSubmitter{}, DefaultOptions().SuppressLogs(), sequence)
+	fmt.Println(result.Committed, err)
+	// Output:
+	// 1utia
+	// 2utia
+	// 3utia
+	// 3 <nil>
+}
@@ -0,0 +1,57 @@
+package txsim
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+)
+
+// fixedGas pins the gas limit and gas price of every transaction of a sequence,
+// overriding the gas the sequence estimates for each operation. This removes
+// the variance of gas estimation from benchmarks. A zero limit disables it and
+// a zero price falls back to the default minimum gas price.
+type fixedGas struct {
+	limit uint64
+	price float64
+}
+
+// validate checks that the gas price is not below the minimum gas price
+// accepted by default.
+func (g fixedGas) validate() error {
+	if g.price != 0 && g.price < appconsts.DefaultMinGasPrice {
+		return fmt.Errorf("gas price %v is below the minimum gas price %v", g.price, appconsts.DefaultMinGasPrice)
+	}
+	return nil
+}
+
+// apply replaces the estimated gas limit of the operation with the fixed gas
+// limit and price, if set. It errors if the fixed gas limit is below the
+// estimate as the transaction would run out of gas.
+func (g fixedGas) apply(op *Operation) error {
+	if g.limit == 0 {
+		return nil
+	}
+	if err := g.validate(); err != nil {
+		return err
+	}
+	if g.limit < op.GasLimit {
+		return fmt.Errorf("gas limit %d is below the estimated gas %d", g.limit, op.GasLimit)
+	}
+	op.GasLimit = g.limit
+	op.GasPrice = g.price
+	return nil
+}
+
+// fee returns the fee paid for each transaction given the gas limit that
+// is used if the gas isn't fixed.
+func (g fixedGas) fee(defaultLimit uint64) uint64 {
+	limit, price := defaultLimit, appconsts.DefaultMinGasPrice
+	if g.limit != 0 {
+		limit = g.limit
+		if g.price != 0 {
+			price = g.price
+		}
+	}
+	return uint64(math.Ceil(float64(limit) * price))
+}
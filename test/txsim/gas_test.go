@@ -0,0 +1,46 @@
+package txsim
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixedGas(t *testing.T) {
+	op := Operation{GasLimit: SendGasLimit}
+	require.NoError(t, fixedGas{}.apply(&op))
+	require.EqualValues(t, SendGasLimit, op.GasLimit)
+	require.Zero(t, op.GasPrice)
+
+	op = Operation{GasLimit: SendGasLimit}
+	require.NoError(t, fixedGas{limit: 150000, price: 0.1}.apply(&op))
+	require.EqualValues(t, 150000, op.GasLimit)
+	require.Equal(t, 0.1, op.GasPrice)
+
+	op = Operation{GasLimit: SendGasLimit}
+	require.ErrorContains(t, fixedGas{limit: 1000}.apply(&op), "below the estimated gas")
+	require.ErrorContains(t, fixedGas{limit: SendGasLimit, price: 0.0001}.apply(&op), "below the minimum gas price")
+
+	require.EqualValues(t, sendFee, fixedGas{}.fee(SendGasLimit))
+	require.EqualValues(t, 15000, fixedGas{limit: 150000, price: 0.1}.fee(SendGasLimit))
+}
+
+func TestBlobSequenceFixedGas(t *testing.T) {
+	sequence := NewBlobSequence(NewRange(1000, 1000), NewRange(1, 1)).WithGas(1_000_000, 0.1)
+	allocate := func(n, _ int) []types.AccAddress {
+		return []types.AccAddress{make([]byte, 20)}
+	}
+	sequence.Init(context.Background(), nil, allocate, rand.New(rand.NewSource(1)), false)
+
+	op, err := sequence.Next(context.Background(), nil, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	require.EqualValues(t, 1_000_000, op.GasLimit)
+	require.Equal(t, 0.1, op.GasPrice)
+
+	sequence.WithGas(1000, 0)
+	_, err = sequence.Next(context.Background(), nil, rand.New(rand.NewSource(1)))
+	require.ErrorContains(t, err, "below the estimated gas")
+}
@@ -0,0 +1,207 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	oldgov "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	"github.com/cosmos/cosmos-sdk/x/params/types/proposal"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var _ Sequence = &GovSequence{}
+
+// GovSequence sets up a sequence whereby a proposer submits param change proposals
+// and a group of voters cast randomized votes on them while they are in the voting
+// period. Each proposal includes the minimum deposit so that it immediately enters
+// the voting period. The proposals only ever set a parameter to its existing value
+// so they have no effect on the network if they pass. Each voter votes a limited
+// number of times on each proposal. The sequence ends once all proposals have
+// been submitted and all votes on them have been cast.
+type GovSequence struct {
+	numVoters    int
+	numProposals int
+	// weights for yes, no, abstain and no with veto respectively
+	voteWeights   [4]int
+	votesPerVoter int
+
+	proposer   types.AccAddress
+	voters     []types.AccAddress
+	minDeposit types.Coins
+	submitted  int
+	// votes is the number of votes cast on each proposal by its id
+	votes   map[uint64]int
+	initErr error
+}
+
+// NewGovSequence creates a sequence with numVoters voting on numProposals
+// proposals one after the other. By default, each vote option is equally
+// likely and each voter votes once on each proposal. Once all votes on a
+// proposal have been cast, or without voters, the next proposal is submitted
+// without waiting for the voting period of the previous one to end.
+func NewGovSequence(numVoters, numProposals int) *GovSequence {
+	return &GovSequence{
+		numVoters:     numVoters,
+		numProposals:  numProposals,
+		voteWeights:   [4]int{1, 1, 1, 1},
+		votesPerVoter: 1,
+	}
+}
+
+// WithVoteWeights sets the relative likelihood of voting yes, no, abstain and
+// no with veto.
+func (s *GovSequence) WithVoteWeights(yes, no, abstain, noWithVeto int) *GovSequence {
+	s.voteWeights = [4]int{yes, no, abstain, noWithVeto}
+	return s
+}
+
+// WithVotesPerVoter sets the number of times each voter votes on each
+// proposal. Later votes replace the earlier votes of the voter.
+func (s *GovSequence) WithVotesPerVoter(n int) *GovSequence {
+	s.votesPerVoter = n
+	return s
+}
+
+func (s *GovSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = &GovSequence{
+			numVoters:     s.numVoters,
+			numProposals:  s.numProposals,
+			voteWeights:   s.voteWeights,
+			votesPerVoter: s.votesPerVoter,
+		}
+	}
+	return sequenceGroup
+}
+
+// Init queries the minimum deposit and allocates the proposer, with enough
+// funds to deposit for each proposal, and the voters.
+func (s *GovSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, _ *rand.Rand, useFeegrant bool) {
+	if s.numProposals < 1 || s.numVoters < 0 || s.votesPerVoter < 1 {
+		s.initErr = fmt.Errorf("gov sequence requires at least one proposal and vote per voter and no negative voters, got %d proposals, %d votes per voter and %d voters", s.numProposals, s.votesPerVoter, s.numVoters)
+		return
+	}
+	resp, err := govv1.NewQueryClient(querier).Params(ctx, &govv1.QueryParamsRequest{ParamsType: govv1.ParamDeposit})
+	if err != nil {
+		s.initErr = fmt.Errorf("querying deposit params: %w", err)
+		return
+	}
+	s.minDeposit = types.NewCoins(resp.DepositParams.MinDeposit...)
+
+	funds := fundsForGas
+	if useFeegrant {
+		funds = 1
+	}
+	// the deposit is set by governance so the proposer's balance may not fit
+	// in an int
+	balance := s.minDeposit.AmountOf(appconsts.BondDenom).MulRaw(int64(s.numProposals)).AddRaw(int64(funds))
+	if balance.GT(types.NewInt(math.MaxInt)) {
+		s.initErr = fmt.Errorf("gov sequence proposer balance of %s%s for %d proposals overflows", balance, appconsts.BondDenom, s.numProposals)
+		return
+	}
+	s.proposer = allocateAccounts(1, int(balance.Int64()))[0]
+	if s.numVoters > 0 {
+		s.voters = allocateAccounts(s.numVoters, funds)
+	}
+	s.votes = make(map[uint64]int)
+}
+
+// Next submits a proposal if there are no proposals from the proposer in the
+// voting period that still have votes to be cast. Otherwise the next voter of
+// one of these proposals casts a random vote on it.
+func (s *GovSequence) Next(ctx context.Context, querier grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	if s.initErr != nil {
+		return Operation{}, s.initErr
+	}
+
+	resp, err := govv1.NewQueryClient(querier).Proposals(ctx, &govv1.QueryProposalsRequest{
+		ProposalStatus: govv1.ProposalStatus_PROPOSAL_STATUS_VOTING_PERIOD,
+		Depositor:      s.proposer.String(),
+	})
+	if err != nil {
+		return Operation{}, fmt.Errorf("querying active proposals: %w", err)
+	}
+
+	maxVotes := len(s.voters) * s.votesPerVoter
+	var open []*govv1.Proposal
+	for _, proposal := range resp.Proposals {
+		if s.votes[proposal.Id] < maxVotes {
+			open = append(open, proposal)
+		}
+	}
+
+	// once all votes have been cast, or without voters, there is nothing to do
+	// while a proposal is in its voting period so the next proposal is
+	// submitted right away
+	if len(open) == 0 {
+		if s.submitted >= s.numProposals {
+			return Operation{}, ErrEndOfSequence
+		}
+		msg, err := s.newProposal(ctx, querier)
+		if err != nil {
+			return Operation{}, err
+		}
+		s.submitted++
+		return Operation{Msgs: []types.Msg{msg}}, nil
+	}
+
+	proposal := open[rand.Intn(len(open))]
+	voter := s.voters[s.votes[proposal.Id]%len(s.voters)]
+	s.votes[proposal.Id]++
+	return Operation{
+		Msgs: []types.Msg{govv1.NewMsgVote(voter, proposal.Id, s.randomVoteOption(rand), "")},
+	}, nil
+}
+
+// newProposal creates a param change proposal that sets the staking max
+// entries param to its current value.
+func (s *GovSequence) newProposal(ctx context.Context, querier grpc.ClientConn) (types.Msg, error) {
+	resp, err := staking.NewQueryClient(querier).Params(ctx, &staking.QueryParamsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("querying staking params: %w", err)
+	}
+	change := proposal.NewParamChange(
+		staking.ModuleName,
+		string(staking.KeyMaxEntries),
+		fmt.Sprintf("%d", resp.Params.MaxEntries),
+	)
+	content := proposal.NewParameterChangeProposal(
+		"txsim",
+		fmt.Sprintf("txsim proposal %d", s.submitted+1),
+		[]proposal.ParamChange{change},
+	)
+	return oldgov.NewMsgSubmitProposal(content, s.minDeposit, s.proposer)
+}
+
+// randomVoteOption picks a vote option according to the vote weights. If no
+// weights are set, it always votes yes.
+func (s *GovSequence) randomVoteOption(rand *rand.Rand) govv1.VoteOption {
+	options := [4]govv1.VoteOption{
+		govv1.OptionYes,
+		govv1.OptionNo,
+		govv1.OptionAbstain,
+		govv1.OptionNoWithVeto,
+	}
+	total := 0
+	for _, weight := range s.voteWeights {
+		total += weight
+	}
+	if total <= 0 {
+		return govv1.OptionYes
+	}
+	n := rand.Intn(total)
+	for i, weight := range s.voteWeights {
+		if n < weight {
+			return options[i]
+		}
+		n -= weight
+	}
+	return govv1.OptionYes
+}
@@ -0,0 +1,89 @@
+package txsim
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	oldgov "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// govQuerier answers the queries of the gov sequence with a fixed minimum
+// deposit and set of active proposals.
+type govQuerier struct {
+	minDeposit types.Coins
+	proposals  []*govv1.Proposal
+}
+
+func (q *govQuerier) Invoke(_ context.Context, _ string, _, reply interface{}, _ ...grpc.CallOption) error {
+	switch resp := reply.(type) {
+	case *govv1.QueryParamsResponse:
+		resp.DepositParams = &govv1.DepositParams{MinDeposit: q.minDeposit}
+	case *govv1.QueryProposalsResponse:
+		resp.Proposals = q.proposals
+	case *staking.QueryParamsResponse:
+		resp.Params = staking.DefaultParams()
+	}
+	return nil
+}
+
+func (q *govQuerier) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	panic("not implemented")
+}
+
+func TestGovSequence(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ctx := context.Background()
+
+	t.Run("caps the votes of each voter on a proposal", func(t *testing.T) {
+		querier := &govQuerier{minDeposit: types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, 100))}
+		seq := NewGovSequence(2, 1).WithVotesPerVoter(2)
+		seq.Init(ctx, querier, testAllocator, r, false)
+
+		op, err := seq.Next(ctx, querier, r)
+		require.NoError(t, err)
+		require.IsType(t, &oldgov.MsgSubmitProposal{}, op.Msgs[0])
+
+		querier.proposals = []*govv1.Proposal{{Id: 1}}
+		voters := make(map[string]int)
+		for i := 0; i < 4; i++ {
+			op, err := seq.Next(ctx, querier, r)
+			require.NoError(t, err)
+			vote, ok := op.Msgs[0].(*govv1.MsgVote)
+			require.True(t, ok)
+			require.EqualValues(t, 1, vote.ProposalId)
+			voters[vote.Voter]++
+		}
+		require.Len(t, voters, 2)
+		for _, votes := range voters {
+			require.Equal(t, 2, votes)
+		}
+
+		// all proposals have been submitted and voted on
+		_, err = seq.Next(ctx, querier, r)
+		require.ErrorIs(t, err, ErrEndOfSequence)
+	})
+
+	t.Run("rejects a deposit that overflows the proposer's balance", func(t *testing.T) {
+		querier := &govQuerier{minDeposit: types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, math.MaxInt64/2))}
+		seq := NewGovSequence(1, 3)
+		seq.Init(ctx, querier, testAllocator, r, false)
+		_, err := seq.Next(ctx, querier, r)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrEndOfSequence)
+	})
+
+	t.Run("rejects no votes per voter", func(t *testing.T) {
+		seq := NewGovSequence(1, 1).WithVotesPerVoter(0)
+		seq.Init(ctx, nil, testAllocator, r, false)
+		_, err := seq.Next(ctx, nil, r)
+		require.Error(t, err)
+	})
+}
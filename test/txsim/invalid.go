@@ -0,0 +1,164 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/v2/test/util/blobfactory"
+	ns "github.com/celestiaorg/go-square/namespace"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var (
+	_ Sequence      = &InvalidSequence{}
+	_ ResultHandler = &InvalidSequence{}
+)
+
+// Mutation invalidates an otherwise valid operation so that the node rejects
+// it.
+type Mutation string
+
+const (
+	// UnderpaidFee sets the gas price below the default minimum gas price of
+	// the node so that the transaction is rejected by the fee check.
+	UnderpaidFee Mutation = "underpaid_fee"
+	// OversizedBlob replaces the blobs of a PFB with a single blob that is
+	// larger than the default maximum block size. It only applies to
+	// operations with blobs.
+	OversizedBlob Mutation = "oversized_blob"
+	// BadSignature corrupts the signature of the transaction. It only applies
+	// to operations without blobs.
+	BadSignature Mutation = "bad_signature"
+)
+
+// ParseMutation returns the mutation with the given name.
+func ParseMutation(name string) (Mutation, error) {
+	switch Mutation(name) {
+	case UnderpaidFee, OversizedBlob, BadSignature:
+		return Mutation(name), nil
+	default:
+		return "", fmt.Errorf("unknown mutation %q, expected %q, %q or %q", name, UnderpaidFee, OversizedBlob, BadSignature)
+	}
+}
+
+// applies returns whether the mutation can be applied to the operation.
+func (m Mutation) applies(op Operation) bool {
+	switch m {
+	case OversizedBlob:
+		return len(op.Blobs) > 0
+	case BadSignature:
+		return len(op.Blobs) == 0
+	default:
+		return true
+	}
+}
+
+// apply invalidates the operation and marks it as expected to be rejected.
+func (m Mutation) apply(op *Operation, useFeegrant bool) error {
+	switch m {
+	case UnderpaidFee:
+		if op.GasLimit == 0 {
+			op.GasLimit = DefaultGasLimit
+		}
+		op.GasPrice = appconsts.DefaultMinGasPrice / 10
+	case OversizedBlob:
+		namespace, err := ns.New(uint8(op.Blobs[0].NamespaceVersion), op.Blobs[0].NamespaceId)
+		if err != nil {
+			return err
+		}
+		size := appconsts.DefaultMaxBytes + 1
+		op.Blobs = blobfactory.RandBlobsWithNamespace([]ns.Namespace{namespace}, []int{size})
+		// pay for the gas so that the blob is rejected for its size
+		op.GasLimit = estimateGas([]int{size}, useFeegrant)
+	case BadSignature:
+		op.corruptSignature = true
+	}
+	op.ExpectRejection = true
+	return nil
+}
+
+// InvalidSequence wraps a sequence and invalidates a fraction of its operations
+// with one of the configured mutations, chosen at random among those that
+// apply to the operation. The node is expected to reject the invalid
+// transactions. Their error codes are recorded in the SequenceResult rather
+// than terminating the sequence. Operations to which no mutation applies are
+// submitted unchanged. This exercises the ante handler and the mempool's
+// rejection paths under load.
+type InvalidSequence struct {
+	inner     Sequence
+	mutations []Mutation
+	rate      float64
+
+	useFeegrant bool
+}
+
+// NewInvalidSequence invalidates every operation of the inner sequence with one
+// of the mutations.
+func NewInvalidSequence(inner Sequence, mutations ...Mutation) *InvalidSequence {
+	return &InvalidSequence{
+		inner:     inner,
+		mutations: mutations,
+		rate:      1,
+	}
+}
+
+// WithRate sets the fraction of operations, between 0 and 1, that are
+// invalidated. The remaining operations are submitted unchanged.
+func (s *InvalidSequence) WithRate(rate float64) *InvalidSequence {
+	s.rate = rate
+	return s
+}
+
+func (s *InvalidSequence) Clone(n int) []Sequence {
+	inner := s.inner.Clone(n)
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewInvalidSequence(inner[i], s.mutations...).WithRate(s.rate)
+	}
+	return sequenceGroup
+}
+
+func (s *InvalidSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, rand *rand.Rand, useFeegrant bool) {
+	s.useFeegrant = useFeegrant
+	s.inner.Init(ctx, querier, allocateAccounts, rand, useFeegrant)
+}
+
+// Next returns the next operation of the inner sequence, invalidated with a
+// random applicable mutation at the configured rate.
+func (s *InvalidSequence) Next(ctx context.Context, querier grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	op, err := s.inner.Next(ctx, querier, rand)
+	if err != nil {
+		return Operation{}, err
+	}
+	if s.rate < 1 && rand.Float64() >= s.rate {
+		return op, nil
+	}
+
+	applicable := make([]Mutation, 0, len(s.mutations))
+	for _, mutation := range s.mutations {
+		if mutation.applies(op) {
+			applicable = append(applicable, mutation)
+		}
+	}
+	if len(applicable) == 0 {
+		return op, nil
+	}
+	if err := applicable[rand.Intn(len(applicable))].apply(&op, s.useFeegrant); err != nil {
+		return Operation{}, err
+	}
+	return op, nil
+}
+
+// OnResult passes the results to the inner sequence.
+func (s *InvalidSequence) OnResult(ctx context.Context, results []OperationResult) {
+	forwardResults(ctx, s.inner, results)
+}
+
+// isRejection returns whether the node responded to the transaction with an
+// error code.
+func isRejection(res *types.TxResponse) bool {
+	return res != nil && res.Code != 0
+}
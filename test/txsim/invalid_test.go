@@ -0,0 +1,115 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/celestia-app/v2/test/util/testnode"
+	"github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// testAllocator returns random addresses without funding them.
+func testAllocator(n, _ int) []types.AccAddress {
+	addresses := make([]types.AccAddress, n)
+	for i := range addresses {
+		addresses[i] = testnode.RandomAddress().(types.AccAddress)
+	}
+	return addresses
+}
+
+func TestInvalidSequence(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	mutations := []Mutation{UnderpaidFee, OversizedBlob, BadSignature}
+
+	t.Run("underpaid fee", func(t *testing.T) {
+		seq := NewInvalidSequence(NewSendSequence(2, 1000, 10), UnderpaidFee)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		op, err := seq.Next(context.Background(), nil, r)
+		require.NoError(t, err)
+		require.True(t, op.ExpectRejection)
+		require.Less(t, op.GasPrice, appconsts.DefaultMinGasPrice)
+		require.Positive(t, op.GasPrice)
+	})
+
+	t.Run("oversized blob only applies to blobs", func(t *testing.T) {
+		seq := NewInvalidSequence(NewBlobSequence(NewRange(100, 100), NewRange(2, 2)), mutations...)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		for i := 0; i < 20; i++ {
+			op, err := seq.Next(context.Background(), nil, r)
+			require.NoError(t, err)
+			require.True(t, op.ExpectRejection)
+			require.False(t, op.corruptSignature)
+			if op.GasPrice == 0 {
+				require.Len(t, op.Blobs, 1)
+				require.Greater(t, len(op.Blobs[0].Data), appconsts.DefaultMaxBytes)
+			}
+		}
+	})
+
+	t.Run("bad signature only applies without blobs", func(t *testing.T) {
+		seq := NewInvalidSequence(NewSendSequence(2, 1000, 10), OversizedBlob, BadSignature)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		op, err := seq.Next(context.Background(), nil, r)
+		require.NoError(t, err)
+		require.True(t, op.ExpectRejection)
+		require.True(t, op.corruptSignature)
+	})
+
+	t.Run("inapplicable mutations leave the operation unchanged", func(t *testing.T) {
+		seq := NewInvalidSequence(NewSendSequence(2, 1000, 10), OversizedBlob)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		op, err := seq.Next(context.Background(), nil, r)
+		require.NoError(t, err)
+		require.False(t, op.ExpectRejection)
+	})
+
+	t.Run("rate", func(t *testing.T) {
+		seq := NewInvalidSequence(NewSendSequence(2, 1000, 1000), UnderpaidFee).WithRate(0.25)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		invalid := 0
+		for i := 0; i < 1000; i++ {
+			op, err := seq.Next(context.Background(), nil, r)
+			require.NoError(t, err)
+			if op.ExpectRejection {
+				invalid++
+			}
+		}
+		require.InDelta(t, 250, invalid, 50)
+	})
+}
+
+func TestParseMutation(t *testing.T) {
+	mutation, err := ParseMutation("bad_signature")
+	require.NoError(t, err)
+	require.Equal(t, BadSignature, mutation)
+
+	_, err = ParseMutation("bad_memo")
+	require.Error(t, err)
+}
+
+func TestRunSequencesRecordsRejections(t *testing.T) {
+	code := RejectionCode{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrUnauthorized.ABCICode()}
+	submit := func(_ context.Context, op Operation) (submitResult, error) {
+		if op.ExpectRejection {
+			res := &types.TxResponse{Codespace: code.Codespace, Code: code.Code}
+			return submitResult{response: res}, errors.New("signature verification failed")
+		}
+		return submitResult{response: &types.TxResponse{}}, nil
+	}
+
+	seq := NewInvalidSequence(&countingSequence{length: 100}, UnderpaidFee).WithRate(0.5)
+	stats, err := runSequences(context.Background(), DefaultOptions(), nil, submit, nil, nil, []Sequence{seq})
+	// the rejections don't terminate the sequence
+	require.NoError(t, err)
+	result := newRunResult(stats)
+	require.Equal(t, 100, result.Submitted)
+	require.Equal(t, 100, result.Committed+result.Rejected)
+	require.Positive(t, result.Rejected)
+	require.Zero(t, result.Errored)
+	require.Equal(t, map[RejectionCode]int{code: result.Rejected}, result.Sequences[0].Rejections)
+}
@@ -0,0 +1,27 @@
+package txsim
+
+import (
+	"io"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// LogFormat determines how each log line is encoded.
+type LogFormat int
+
+const (
+	// ConsoleLogFormat writes human readable lines.
+	ConsoleLogFormat LogFormat = iota
+	// JSONLogFormat writes a JSON object per line for machine parsing.
+	JSONLogFormat
+)
+
+// newLogger returns a logger that writes to w in the given format. Each line
+// is timestamped.
+func newLogger(w io.Writer, format LogFormat) zerolog.Logger {
+	if format == ConsoleLogFormat {
+		w = zerolog.ConsoleWriter{Out: w, TimeFormat: time.RFC3339}
+	}
+	return zerolog.New(w).With().Timestamp().Logger()
+}
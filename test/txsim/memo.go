@@ -0,0 +1,97 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var (
+	_ Sequence      = &MemoSequence{}
+	_ ResultHandler = &MemoSequence{}
+)
+
+// memoCharset is the set of characters from which memos are drawn.
+const memoCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// MemoSequence wraps a sequence and attaches a random memo of a fixed length to
+// each of its operations. Memos are drawn from the seeded random source so
+// they are deterministic. As the memo increases the size of the transaction,
+// the gas limit of operations that set one is raised by the cost of the memo's
+// bytes. The length is checked against the chain's maximum memo length when
+// the sequence is initialized.
+type MemoSequence struct {
+	inner  Sequence
+	length int
+
+	txSizeCostPerByte uint64
+	initErr           error
+}
+
+// NewMemoSequence attaches a random memo of length characters to each
+// operation of the inner sequence.
+func NewMemoSequence(inner Sequence, length int) *MemoSequence {
+	return &MemoSequence{inner: inner, length: length}
+}
+
+func (s *MemoSequence) Clone(n int) []Sequence {
+	inner := s.inner.Clone(n)
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewMemoSequence(inner[i], s.length)
+	}
+	return sequenceGroup
+}
+
+// Init checks the memo length against the auth params of the chain and
+// initializes the inner sequence.
+func (s *MemoSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, rand *rand.Rand, useFeegrant bool) {
+	s.inner.Init(ctx, querier, allocateAccounts, rand, useFeegrant)
+	if s.length < 0 {
+		s.initErr = fmt.Errorf("memo length must not be negative, got %d", s.length)
+		return
+	}
+	resp, err := auth.NewQueryClient(querier).Params(ctx, &auth.QueryParamsRequest{})
+	if err != nil {
+		s.initErr = fmt.Errorf("querying auth params: %w", err)
+		return
+	}
+	if uint64(s.length) > resp.Params.MaxMemoCharacters {
+		s.initErr = fmt.Errorf("memo length %d exceeds the maximum of %d", s.length, resp.Params.MaxMemoCharacters)
+		return
+	}
+	s.txSizeCostPerByte = resp.Params.TxSizeCostPerByte
+}
+
+// Next returns the next operation of the inner sequence with a random memo.
+func (s *MemoSequence) Next(ctx context.Context, querier grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	if s.initErr != nil {
+		return Operation{}, s.initErr
+	}
+	op, err := s.inner.Next(ctx, querier, rand)
+	if err != nil {
+		return Operation{}, err
+	}
+	op.Memo = randomMemo(rand, s.length)
+	if op.GasLimit != 0 {
+		op.GasLimit += uint64(s.length) * s.txSizeCostPerByte
+	}
+	return op, nil
+}
+
+// OnResult passes the results to the inner sequence.
+func (s *MemoSequence) OnResult(ctx context.Context, results []OperationResult) {
+	forwardResults(ctx, s.inner, results)
+}
+
+// randomMemo returns a memo of length alphanumeric characters.
+func randomMemo(rand *rand.Rand, length int) string {
+	memo := make([]byte, length)
+	for i := range memo {
+		memo[i] = memoCharset[rand.Intn(len(memoCharset))]
+	}
+	return string(memo)
+}
@@ -0,0 +1,79 @@
+package txsim
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	auth "github.com/cosmos/cosmos-sdk/x/auth/types"
+	gogogrpc "github.com/gogo/protobuf/grpc"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// authParamsConn answers auth params queries with the default params.
+type authParamsConn struct {
+	gogogrpc.ClientConn
+}
+
+func (c *authParamsConn) Invoke(_ context.Context, _ string, _, reply interface{}, _ ...grpc.CallOption) error {
+	reply.(*auth.QueryParamsResponse).Params = auth.DefaultParams()
+	return nil
+}
+
+// gasLimitSequence returns operations with a fixed gas limit.
+type gasLimitSequence struct {
+	countingSequence
+	gasLimit uint64
+}
+
+func (s *gasLimitSequence) Next(ctx context.Context, querier gogogrpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	op, err := s.countingSequence.Next(ctx, querier, rand)
+	op.GasLimit = s.gasLimit
+	return op, err
+}
+
+func TestMemoSequence(t *testing.T) {
+	params := auth.DefaultParams()
+
+	t.Run("attaches a deterministic memo", func(t *testing.T) {
+		memos := make([]string, 2)
+		for i := range memos {
+			seq := NewMemoSequence(&countingSequence{length: 1}, 32)
+			seq.Init(context.Background(), &authParamsConn{}, nil, nil, false)
+			op, err := seq.Next(context.Background(), nil, rand.New(rand.NewSource(1)))
+			require.NoError(t, err)
+			require.Len(t, op.Memo, 32)
+			// operations without a gas limit use the default which leaves room for the memo
+			require.Zero(t, op.GasLimit)
+			memos[i] = op.Memo
+		}
+		require.Equal(t, memos[0], memos[1])
+	})
+
+	t.Run("raises the gas limit by the cost of the memo", func(t *testing.T) {
+		seq := NewMemoSequence(&gasLimitSequence{countingSequence: countingSequence{length: 1}, gasLimit: 100_000}, 100)
+		seq.Init(context.Background(), &authParamsConn{}, nil, nil, false)
+		op, err := seq.Next(context.Background(), nil, rand.New(rand.NewSource(1)))
+		require.NoError(t, err)
+		require.Equal(t, 100_000+100*params.TxSizeCostPerByte, op.GasLimit)
+	})
+
+	t.Run("rejects memos longer than the maximum", func(t *testing.T) {
+		seq := NewMemoSequence(&countingSequence{length: 1}, int(params.MaxMemoCharacters)+1)
+		seq.Init(context.Background(), &authParamsConn{}, nil, nil, false)
+		_, err := seq.Next(context.Background(), nil, rand.New(rand.NewSource(1)))
+		require.ErrorContains(t, err, "exceeds the maximum")
+	})
+
+	t.Run("clones the memo length", func(t *testing.T) {
+		clones := NewMemoSequence(&countingSequence{length: 1}, 8).Clone(2)
+		require.Len(t, clones, 2)
+		for _, clone := range clones {
+			clone.Init(context.Background(), &authParamsConn{}, nil, nil, false)
+			op, err := clone.Next(context.Background(), nil, rand.New(rand.NewSource(1)))
+			require.NoError(t, err)
+			require.Len(t, op.Memo, 8)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package txsim
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var _ Sequence = &MultiSendSequence{}
+
+const (
+	// MultiSendBaseGasLimit is the gas limit of a multi-send without outputs
+	MultiSendBaseGasLimit = 100000
+	// MultiSendGasPerOutput is the additional gas limit for each output
+	MultiSendGasPerOutput = 25000
+)
+
+// MultiSendSequence sets up a sequence of multi-send transactions, each
+// distributing randomized amounts from a single input account to a set of
+// output accounts
+type MultiSendSequence struct {
+	numOutputs    int
+	maxAmount     int
+	numIterations int
+	input         types.AccAddress
+	outputs       []types.AccAddress
+	index         int
+}
+
+// NewMultiSendSequence creates a sequence of numIterations multi-sends, each
+// sending between 1 and maxAmount utia to each of numOutputs accounts.
+func NewMultiSendSequence(numOutputs, maxAmount, numIterations int) *MultiSendSequence {
+	return &MultiSendSequence{
+		numOutputs:    numOutputs,
+		maxAmount:     maxAmount,
+		numIterations: numIterations,
+	}
+}
+
+func (s *MultiSendSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewMultiSendSequence(s.numOutputs, s.maxAmount, s.numIterations)
+	}
+	return sequenceGroup
+}
+
+// Init sets up the accounts involved in the sequence. The input account is
+// funded with the maximum amount sent to every output plus the fee for each
+// iteration. The output accounts are only funded with the minimum balance.
+func (s *MultiSendSequence) Init(_ context.Context, _ grpc.ClientConn, allocateAccounts AccountAllocator, _ *rand.Rand, _ bool) {
+	perIteration := s.numOutputs*s.maxAmount + int(math.Ceil(float64(s.gasLimit())*appconsts.DefaultMinGasPrice))
+	s.input = allocateAccounts(1, s.numIterations*perIteration)[0]
+	s.outputs = allocateAccounts(s.numOutputs, 1)
+}
+
+// Next submits a multi-send from the input account to all output accounts
+func (s *MultiSendSequence) Next(_ context.Context, _ grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	if s.index >= s.numIterations {
+		return Operation{}, ErrEndOfSequence
+	}
+
+	total := int64(0)
+	outputs := make([]bank.Output, len(s.outputs))
+	for i, address := range s.outputs {
+		amount := rand.Int63n(int64(s.maxAmount)) + 1
+		total += amount
+		outputs[i] = bank.NewOutput(address, types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, amount)))
+	}
+	input := bank.NewInput(s.input, types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, total)))
+
+	op := Operation{
+		Msgs:     []types.Msg{bank.NewMsgMultiSend([]bank.Input{input}, outputs)},
+		GasLimit: s.gasLimit(),
+	}
+	s.index++
+	return op, nil
+}
+
+// gasLimit returns the gas limit of a multi-send to all outputs
+func (s *MultiSendSequence) gasLimit() uint64 {
+	return uint64(MultiSendBaseGasLimit + s.numOutputs*MultiSendGasPerOutput)
+}
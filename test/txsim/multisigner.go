@@ -0,0 +1,91 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var _ Sequence = &MultiSignerSequence{}
+
+// MultiSignerSequence sets up a sequence of transactions that are each signed
+// by several accounts. Every transaction contains a send from each account to
+// the next one, in a ring, so the balances only change by the fee which is
+// paid by the first account. The sequence number of each signer is
+// incremented by every transaction.
+type MultiSignerSequence struct {
+	numSigners    int
+	sendAmount    int
+	numIterations int
+	accounts      []types.AccAddress
+	index         int
+	initErr       error
+}
+
+// NewMultiSignerSequence creates a sequence of numIterations transactions,
+// each signed by numSigners accounts that send sendAmount utia to one another.
+func NewMultiSignerSequence(numSigners, sendAmount, numIterations int) *MultiSignerSequence {
+	return &MultiSignerSequence{
+		numSigners:    numSigners,
+		sendAmount:    sendAmount,
+		numIterations: numIterations,
+	}
+}
+
+func (s *MultiSignerSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewMultiSignerSequence(s.numSigners, s.sendAmount, s.numIterations)
+	}
+	return sequenceGroup
+}
+
+// Init sets up the accounts involved in the sequence. The first account pays
+// the fee of every transaction so it is funded with the fees on top of the
+// amount sent.
+func (s *MultiSignerSequence) Init(_ context.Context, _ grpc.ClientConn, allocateAccounts AccountAllocator, _ *rand.Rand, _ bool) {
+	if s.numSigners < 2 {
+		s.initErr = fmt.Errorf("multi signer sequence requires at least two signers, got %d", s.numSigners)
+		return
+	}
+	fee := int(math.Ceil(float64(s.gasLimit()) * appconsts.DefaultMinGasPrice))
+	s.accounts = allocateAccounts(1, s.sendAmount+s.numIterations*fee)
+	s.accounts = append(s.accounts, allocateAccounts(s.numSigners-1, s.sendAmount)...)
+}
+
+// Next submits a transaction with a send from each account to the next one
+func (s *MultiSignerSequence) Next(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) (Operation, error) {
+	if s.initErr != nil {
+		return Operation{}, s.initErr
+	}
+	if s.index >= s.numIterations {
+		return Operation{}, ErrEndOfSequence
+	}
+
+	amount := types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, int64(s.sendAmount)))
+	msgs := make([]types.Msg, len(s.accounts))
+	for i, from := range s.accounts {
+		to := s.accounts[(i+1)%len(s.accounts)]
+		msgs[i] = bank.NewMsgSend(from, to, amount)
+	}
+
+	op := Operation{
+		Msgs:     msgs,
+		GasLimit: s.gasLimit(),
+	}
+	s.index++
+	return op, nil
+}
+
+// gasLimit returns the gas limit of a transaction with a send from each
+// account. Every signature adds to the gas used so it scales with the number of
+// signers.
+func (s *MultiSignerSequence) gasLimit() uint64 {
+	return uint64(SendGasLimit * s.numSigners)
+}
@@ -0,0 +1,31 @@
+package txsim
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiSignerSequence(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	t.Run("signs with every account", func(t *testing.T) {
+		seq := NewMultiSignerSequence(3, 100, 1)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		op, err := seq.Next(context.Background(), nil, r)
+		require.NoError(t, err)
+		require.Len(t, op.Msgs, 3)
+		_, err = seq.Next(context.Background(), nil, r)
+		require.ErrorIs(t, err, ErrEndOfSequence)
+	})
+
+	t.Run("requires two signers", func(t *testing.T) {
+		seq := NewMultiSignerSequence(1, 100, 1)
+		seq.Init(context.Background(), nil, testAllocator, r, false)
+		_, err := seq.Next(context.Background(), nil, r)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrEndOfSequence)
+	})
+}
@@ -0,0 +1,80 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/rs/zerolog/log"
+)
+
+// refillPollMultiplier dictates how often, in multiples of the poll time, the
+// balances of the subaccounts are checked when auto refill is enabled.
+const refillPollMultiplier = 10
+
+// autoRefill periodically checks the balances of all subaccounts and refills
+// those that have dropped below the threshold until the context is cancelled.
+func (am *AccountManager) autoRefill(ctx context.Context, threshold uint64) {
+	ticker := time.NewTicker(am.pollTime * refillPollMultiplier)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := am.refill(ctx, threshold); err != nil && ctx.Err() == nil {
+				log.Error().Err(err).Msg("failed to refill accounts")
+			}
+		}
+	}
+}
+
+// refill tops up all subaccounts whose balance is below the threshold back to
+// the balance they were initially funded with. All refills are batched into a
+// single transaction from the master account.
+func (am *AccountManager) refill(ctx context.Context, threshold uint64) error {
+	am.mtx.Lock()
+	fundings := make(map[string]uint64, len(am.fundings))
+	for address, funding := range am.fundings {
+		fundings[address] = funding
+	}
+	am.mtx.Unlock()
+
+	// sort the addresses so that the refill transaction is deterministic
+	addresses := make([]string, 0, len(fundings))
+	for address := range fundings {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	msgs := make([]types.Msg, 0)
+	for _, address := range addresses {
+		accAddress, err := types.AccAddressFromBech32(address)
+		if err != nil {
+			return err
+		}
+		balance, err := am.getBalance(ctx, accAddress)
+		if err != nil {
+			return err
+		}
+		if balance >= threshold || balance >= fundings[address] {
+			continue
+		}
+		amount := types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, int64(fundings[address]-balance)))
+		msgs = append(msgs, bank.NewMsgSend(am.master.Address(), accAddress, amount))
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	if err := am.Submit(ctx, Operation{Msgs: msgs, GasLimit: uint64(SendGasLimit * len(msgs))}); err != nil {
+		return fmt.Errorf("refilling %d accounts: %w", len(msgs), err)
+	}
+	log.Info().Int("accounts", len(msgs)).Msg("refilled accounts")
+	return nil
+}
@@ -0,0 +1,114 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/types"
+	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// refundTimeout bounds the time taken to refund the subaccounts on shutdown.
+	refundTimeout = 2 * time.Minute
+	// refundBatchSize is the number of refund transactions that are submitted
+	// concurrently.
+	refundBatchSize = 50
+	// refundFee is the fee paid by a subaccount to send its balance back to
+	// the master account.
+	refundFee = uint64(sendFee)
+)
+
+// Refund sweeps the remaining spendable balance of each subaccount, minus the
+// fee, back to the master account. The fee grants of the subaccounts are
+// revoked first so that each subaccount pays its own fee. Each subaccount
+// sends its balance in its own transaction and these are submitted
+// concurrently in batches. Subaccounts that fail to be refunded are logged and
+// skipped. It returns the total amount that was refunded.
+func (am *AccountManager) Refund(ctx context.Context) (uint64, error) {
+	am.revokeFeeGrants(ctx)
+
+	am.mtx.Lock()
+	addresses := make([]string, 0, len(am.subaccounts))
+	for address := range am.subaccounts {
+		addresses = append(addresses, address)
+	}
+	am.mtx.Unlock()
+	// sort for a deterministic order of transactions
+	sort.Strings(addresses)
+
+	var (
+		mtx      sync.Mutex
+		refunded uint64
+		failed   int
+	)
+	for start := 0; start < len(addresses); start += refundBatchSize {
+		end := min(start+refundBatchSize, len(addresses))
+		var wg sync.WaitGroup
+		for _, address := range addresses[start:end] {
+			wg.Add(1)
+			go func(address types.AccAddress) {
+				defer wg.Done()
+				amount, err := am.refundAccount(ctx, address)
+				mtx.Lock()
+				defer mtx.Unlock()
+				if err != nil {
+					failed++
+					log.Warn().Err(err).Str("address", address.String()).Msg("failed to refund account")
+					return
+				}
+				refunded += amount
+			}(types.MustAccAddressFromBech32(address))
+		}
+		wg.Wait()
+		if err := ctx.Err(); err != nil {
+			return refunded, err
+		}
+	}
+
+	log.Info().
+		Uint64("amount", refunded).
+		Int("accounts", len(addresses)-failed).
+		Msg("refunded accounts")
+	if failed > 0 {
+		return refunded, fmt.Errorf("failed to refund %d of %d accounts", failed, len(addresses))
+	}
+	return refunded, nil
+}
+
+// refundAccount sends the spendable balance of the subaccount, minus the fee,
+// to the master account. Accounts whose balance doesn't cover the fee are
+// skipped.
+func (am *AccountManager) refundAccount(ctx context.Context, address types.AccAddress) (uint64, error) {
+	balance, err := am.getSpendableBalance(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	if balance <= refundFee {
+		return 0, nil
+	}
+	amount := balance - refundFee
+	msg := bank.NewMsgSend(address, am.master.Address(), types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, int64(amount))))
+	op := Operation{Msgs: []types.Msg{msg}, GasLimit: SendGasLimit}
+	if err := am.Submit(ctx, op); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
+// getSpendableBalance returns the balance of the account that isn't locked,
+// i.e. by a vesting schedule.
+func (am *AccountManager) getSpendableBalance(ctx context.Context, address types.AccAddress) (uint64, error) {
+	resp, err := bank.NewQueryClient(am.conn).SpendableBalances(ctx, &bank.QuerySpendableBalancesRequest{
+		Address: address.String(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error getting spendable balance for %s: %w", address.String(), err)
+	}
+	return resp.GetBalances().AmountOf(appconsts.BondDenom).Uint64(), nil
+}
@@ -0,0 +1,212 @@
+package txsim
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+
+	"github.com/celestiaorg/go-square/blob"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+// replayEntry is a single line of the replay log. It records an operation that
+// was committed by a sequence.
+type replayEntry struct {
+	Sequence int               `json:"sequence"`
+	Signer   string            `json:"signer"`
+	Nonce    uint64            `json:"nonce"`
+	MsgTypes []string          `json:"msg_types"`
+	Msgs     []json.RawMessage `json:"msgs"`
+	Blobs    []*blob.Blob      `json:"blobs,omitempty"`
+	Delay    uint64            `json:"delay,omitempty"`
+	GasLimit uint64            `json:"gas_limit,omitempty"`
+	GasPrice float64           `json:"gas_price,omitempty"`
+	Memo     string            `json:"memo,omitempty"`
+}
+
+// replayLogger writes every committed operation as newline delimited JSON.
+// This is thread safe.
+type replayLogger struct {
+	mtx  sync.Mutex
+	cdc  codec.Codec
+	file *os.File
+}
+
+func newReplayLogger(path string, cdc codec.Codec) (*replayLogger, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("creating replay log: %w", err)
+	}
+	return &replayLogger{cdc: cdc, file: file}, nil
+}
+
+// record appends the operation to the replay log. A nil logger is a no-op.
+func (l *replayLogger) record(seqID int, op Operation, nonce uint64) error {
+	if l == nil {
+		return nil
+	}
+	entry := replayEntry{
+		Sequence: seqID,
+		Signer:   op.Msgs[0].GetSigners()[0].String(),
+		Nonce:    nonce,
+		MsgTypes: make([]string, len(op.Msgs)),
+		Msgs:     make([]json.RawMessage, len(op.Msgs)),
+		Blobs:    op.Blobs,
+		Delay:    op.Delay,
+		GasLimit: op.GasLimit,
+		GasPrice: op.GasPrice,
+		Memo:     op.Memo,
+	}
+	for i, msg := range op.Msgs {
+		bz, err := l.cdc.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return fmt.Errorf("encoding msg: %w", err)
+		}
+		entry.MsgTypes[i] = types.MsgTypeURL(msg)
+		entry.Msgs[i] = bz
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	_, err = l.file.Write(append(line, '\n'))
+	return err
+}
+
+func (l *replayLogger) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+var _ Sequence = &ReplaySequence{}
+
+// ReplaySequence re-submits the operations recorded in a replay log (see
+// Options.WithReplayLog) in the order in which they were committed. Each signer
+// in the log is substituted with a newly allocated account, in the order in which
+// they first appear, so that the same operations are signed by the same set of
+// accounts. The sequence ends once all operations have been submitted.
+type ReplaySequence struct {
+	path    string
+	cdc     codec.Codec
+	balance int
+
+	entries []replayEntry
+	// accounts maps the signers in the replay log to the allocated accounts
+	accounts map[string]string
+	index    int
+	initErr  error
+}
+
+// NewReplaySequence creates a sequence that replays the log at the path. The
+// codec must be able to decode all messages in the log. Each account is funded
+// with balance utia.
+func NewReplaySequence(path string, cdc codec.Codec, balance int) *ReplaySequence {
+	return &ReplaySequence{
+		path:    path,
+		cdc:     cdc,
+		balance: balance,
+	}
+}
+
+// Clone replicates the sequence. Each clone replays the entire log with a
+// separate set of accounts.
+func (s *ReplaySequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewReplaySequence(s.path, s.cdc, s.balance)
+	}
+	return sequenceGroup
+}
+
+// Init reads the replay log and allocates an account for each signer.
+func (s *ReplaySequence) Init(_ context.Context, _ grpc.ClientConn, allocateAccounts AccountAllocator, _ *rand.Rand, _ bool) {
+	s.entries, s.initErr = readReplayLog(s.path)
+	if s.initErr != nil {
+		return
+	}
+
+	signers := make([]string, 0)
+	s.accounts = make(map[string]string)
+	for _, entry := range s.entries {
+		if _, ok := s.accounts[entry.Signer]; !ok {
+			s.accounts[entry.Signer] = ""
+			signers = append(signers, entry.Signer)
+		}
+	}
+	if len(signers) == 0 {
+		return
+	}
+
+	accounts := allocateAccounts(len(signers), s.balance)
+	for i, signer := range signers {
+		s.accounts[signer] = accounts[i].String()
+	}
+}
+
+// Next returns the next operation in the replay log.
+func (s *ReplaySequence) Next(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) (Operation, error) {
+	if s.initErr != nil {
+		return Operation{}, s.initErr
+	}
+	if s.index >= len(s.entries) {
+		return Operation{}, ErrEndOfSequence
+	}
+	entry := s.entries[s.index]
+	s.index++
+
+	msgs := make([]types.Msg, len(entry.Msgs))
+	for i, bz := range entry.Msgs {
+		// substitute all references of the original accounts
+		for original, replacement := range s.accounts {
+			bz = bytes.ReplaceAll(bz, []byte(original), []byte(replacement))
+		}
+		if err := s.cdc.UnmarshalInterfaceJSON(bz, &msgs[i]); err != nil {
+			return Operation{}, fmt.Errorf("decoding %s: %w", entry.MsgTypes[i], err)
+		}
+	}
+
+	return Operation{
+		Msgs:     msgs,
+		Blobs:    entry.Blobs,
+		Delay:    entry.Delay,
+		GasLimit: entry.GasLimit,
+		GasPrice: entry.GasPrice,
+		Memo:     entry.Memo,
+	}, nil
+}
+
+func readReplayLog(path string) ([]replayEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening replay log: %w", err)
+	}
+	defer file.Close()
+
+	entries := make([]replayEntry, 0)
+	scanner := bufio.NewScanner(file)
+	// blobs can make lines far larger than the default buffer
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var entry replayEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("decoding replay log entry %d: %w", len(entries), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,61 @@
+package txsim
+
+import (
+	"context"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// OperationResult is the on-chain outcome of an operation.
+type OperationResult struct {
+	Operation Operation
+	// TxHash, Height, Code, Codespace and Events are those of the transaction
+	// response. They are empty if the transaction never reached the node, or,
+	// as with RunSequences, if there was no response.
+	TxHash    string
+	Height    int64
+	Code      uint32
+	Codespace string
+	Events    []abci.Event
+	// Err is the error with which the operation failed, if any.
+	Err error
+}
+
+// ResultHandler can optionally be implemented by a Sequence to learn the
+// outcome of its operations, i.e. the id of a proposal it submitted, so that
+// it can adapt its next operations. OnResult is called with the results of
+// each submitted batch of operations, in the order they were returned by Next,
+// before Next is called again. It is called from the same goroutine as Next.
+// Sequences that don't need the results don't implement it.
+type ResultHandler interface {
+	OnResult(ctx context.Context, results []OperationResult)
+}
+
+// handleResults passes the results of the operations to the sequence if it
+// implements ResultHandler.
+func handleResults(ctx context.Context, sequence Sequence, ops []Operation, results []submitResult, errs []error) {
+	handler, ok := sequence.(ResultHandler)
+	if !ok {
+		return
+	}
+	operationResults := make([]OperationResult, len(ops))
+	for i, op := range ops {
+		operationResults[i] = OperationResult{Operation: op, Err: errs[i]}
+		if res := results[i].response; res != nil {
+			operationResults[i].TxHash = res.TxHash
+			operationResults[i].Height = res.Height
+			operationResults[i].Code = res.Code
+			operationResults[i].Codespace = res.Codespace
+			operationResults[i].Events = res.Events
+		}
+	}
+	handler.OnResult(ctx, operationResults)
+}
+
+// forwardResults passes the results to the inner sequence of a wrapper if it
+// implements ResultHandler.
+func forwardResults(ctx context.Context, inner Sequence, results []OperationResult) {
+	if handler, ok := inner.(ResultHandler); ok {
+		handler.OnResult(ctx, results)
+	}
+}
@@ -0,0 +1,66 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/gogo/protobuf/grpc"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestOnResult(t *testing.T) {
+	errRejected := errors.New("rejected")
+	// every other operation is rejected
+	submit := func(_ context.Context, op Operation) (submitResult, error) {
+		res := &types.TxResponse{
+			TxHash: string(rune('A' + op.GasLimit)),
+			Height: int64(op.GasLimit),
+			Events: []abci.Event{{Type: "test"}},
+		}
+		if op.GasLimit%2 == 1 {
+			res.Code = 1
+			return submitResult{response: res}, errRejected
+		}
+		return submitResult{response: res}, nil
+	}
+
+	var (
+		calls   int
+		results []OperationResult
+	)
+	next := func(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) ([]Operation, error) {
+		calls++
+		if calls > 2 {
+			return nil, ErrEndOfSequence
+		}
+		return []Operation{{GasLimit: uint64(2*calls - 2), ExpectRejection: true}, {GasLimit: uint64(2*calls - 1), ExpectRejection: true}}, nil
+	}
+	sequence := NewFuncSequence(nil, next).WithOnResult(func(_ context.Context, batch []OperationResult) {
+		results = append(results, batch...)
+	})
+	// the results are forwarded through wrappers
+	wrapped := NewInvalidSequence(sequence).WithRate(0)
+
+	stats, err := runSequences(context.Background(), DefaultOptions(), nil, submit, nil, nil, []Sequence{wrapped})
+	require.NoError(t, err)
+	require.Equal(t, 4, stats[0].submitted)
+
+	require.Len(t, results, 4)
+	for i, result := range results {
+		require.EqualValues(t, i, result.Operation.GasLimit)
+		require.Equal(t, string(rune('A'+i)), result.TxHash)
+		require.EqualValues(t, i, result.Height)
+		require.Equal(t, []abci.Event{{Type: "test"}}, result.Events)
+		if i%2 == 1 {
+			require.EqualValues(t, 1, result.Code)
+			require.ErrorIs(t, result.Err, errRejected)
+		} else {
+			require.Zero(t, result.Code)
+			require.NoError(t, result.Err)
+		}
+	}
+}
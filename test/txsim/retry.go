@@ -0,0 +1,72 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "github.com/celestiaorg/celestia-app/v2/app/errors"
+	"github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// retryPolicy dictates how many times a submission is attempted and how long
+// to wait between attempts. The delay doubles after each failed attempt.
+type retryPolicy struct {
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+// delay returns the backoff before the next attempt given the number of
+// attempts that have already been made.
+func (p retryPolicy) delay(attempt int) time.Duration {
+	return p.baseDelay * time.Duration(1<<(attempt-1))
+}
+
+// isRetryable returns true if the failed broadcast is likely to succeed if it
+// were attempted again. Only errors that are known to leave the transaction out
+// of the mempool are retried: an unreachable or overloaded node and
+// transactions rejected from the mempool because it is full or because of a
+// stale sequence number. A broadcast that timed out may have reached the
+// mempool and is not retried, as doing so could commit the operation twice.
+// Transactions that were included in a block but failed, or that were rejected
+// for any other reason (i.e. an invalid signature), are permanent.
+func isRetryable(res *types.TxResponse, err error) bool {
+	if err == nil || isContextErr(err) || errors.Is(err, ErrSubmitTimeout) {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+
+	if res == nil || res.Code == 0 || res.Height != 0 || res.Codespace != sdkerrors.RootCodespace {
+		return false
+	}
+
+	return res.Code == sdkerrors.ErrMempoolIsFull.ABCICode() || apperrors.IsNonceMismatchCode(res.Code)
+}
+
+// isNonceMismatch returns true if the transaction was rejected from the mempool
+// because its sequence number didn't match the account's on-chain sequence.
+func isNonceMismatch(res *types.TxResponse, err error) bool {
+	if err == nil || res == nil || res.Height != 0 || res.Codespace != sdkerrors.RootCodespace {
+		return false
+	}
+	return apperrors.IsNonceMismatchCode(res.Code)
+}
+
+// sleep waits for the duration or until the context is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,75 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsRetryable(t *testing.T) {
+	txErr := errors.New("tx failed")
+	testCases := []struct {
+		name      string
+		res       *types.TxResponse
+		err       error
+		retryable bool
+	}{
+		{"no error", nil, nil, false},
+		{"context cancelled", nil, context.Canceled, false},
+		{"submit timeout", nil, fmt.Errorf("%w after 1s: %v", ErrSubmitTimeout, context.DeadlineExceeded), false},
+		{"unavailable", nil, status.Error(codes.Unavailable, "connection reset"), true},
+		{"aborted", nil, status.Error(codes.Aborted, "aborted"), false},
+		{"resource exhausted", nil, status.Error(codes.ResourceExhausted, "too many requests"), true},
+		{"invalid argument", nil, status.Error(codes.InvalidArgument, "bad request"), false},
+		{
+			"mempool full",
+			&types.TxResponse{Code: sdkerrors.ErrMempoolIsFull.ABCICode(), Codespace: sdkerrors.RootCodespace},
+			txErr,
+			true,
+		},
+		{
+			"nonce mismatch",
+			&types.TxResponse{Code: sdkerrors.ErrWrongSequence.ABCICode(), Codespace: sdkerrors.RootCodespace},
+			txErr,
+			true,
+		},
+		{
+			"invalid signature",
+			&types.TxResponse{Code: sdkerrors.ErrUnauthorized.ABCICode(), Codespace: sdkerrors.RootCodespace},
+			txErr,
+			false,
+		},
+		{
+			"included but failed",
+			&types.TxResponse{Code: sdkerrors.ErrWrongSequence.ABCICode(), Codespace: sdkerrors.RootCodespace, Height: 10},
+			txErr,
+			false,
+		},
+		{
+			"other codespace",
+			&types.TxResponse{Code: sdkerrors.ErrMempoolIsFull.ABCICode(), Codespace: "blob"},
+			txErr,
+			false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.retryable, isRetryable(tc.res, tc.err))
+		})
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := retryPolicy{maxAttempts: 4, baseDelay: 100 * time.Millisecond}
+	require.Equal(t, 100*time.Millisecond, policy.delay(1))
+	require.Equal(t, 200*time.Millisecond, policy.delay(2))
+	require.Equal(t, 400*time.Millisecond, policy.delay(3))
+}
@@ -2,22 +2,63 @@ package txsim
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
+	"runtime/debug"
+	"sync/atomic"
 	"time"
 
 	"github.com/celestiaorg/celestia-app/v2/app/encoding"
 	"github.com/celestiaorg/celestia-app/v2/pkg/user"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/gogo/protobuf/grpc"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"golang.org/x/time/rate"
 )
 
 const DefaultSeed = 900183116
 
+// SeedStrategy determines how the random source of each sequence is seeded.
+type SeedStrategy int
+
+const (
+	// PerSequenceSeed seeds each sequence with the seed offset by the index of
+	// the sequence so that cloned sequences generate different transactions.
+	// This is the default. NOTE: this changes the transactions generated for a
+	// given seed compared to earlier versions which always used a shared seed.
+	PerSequenceSeed SeedStrategy = iota
+	// SharedSeed seeds every sequence with the same seed. Cloned sequences
+	// will therefore make identical random choices.
+	SharedSeed
+)
+
+// ErrSequencePanic is returned when a sequence panics. The error includes the
+// recovered value and the stack trace.
+var ErrSequencePanic = errors.New("sequence panicked")
+
+// ErrSubmitTimeout is returned when a submission isn't broadcast or committed
+// within the timeout set by Options.WithSubmitTimeout.
+var ErrSubmitTimeout = errors.New("submission timed out")
+
+// ErrSetupTimeout is returned when the accounts allocated by the sequences
+// aren't funded within the timeout set by Options.WithSetupTimeout.
+var ErrSetupTimeout = errors.New("account setup timed out")
+
+// ErrLatencyThreshold is returned when a larger fraction of the committed
+// transactions than allowed by Options.WithMaxLatencyBreaches exceeded the
+// latency threshold.
+var ErrLatencyThreshold = errors.New("latency threshold breached")
+
+// ErrNoOperations is returned when every sequence ended without submitting a
+// single operation, which usually means that the sequences are misconfigured,
+// i.e. with zero iterations.
+var ErrNoOperations = errors.New("no operations were submitted")
+
 // Run is the entrypoint function for starting the txsim client. The lifecycle of the client is managed
 // through the context. At least one grpc and rpc endpoint must be provided. The client relies on a
 // single funded master account present in the keyring. The client allocates subaccounts for sequences
@@ -36,12 +77,41 @@ func Run(
 	opts *Options,
 	sequences ...Sequence,
 ) error {
+	_, err := RunWithResult(ctx, grpcEndpoint, keys, encCfg, opts, sequences...)
+	return err
+}
+
+// RunWithResult is the same as Run but also returns a summary of the transactions
+// that were submitted, committed and errored as well as their commit latencies.
+// The result is nil if the client failed before any sequences were started.
+func RunWithResult(
+	ctx context.Context,
+	grpcEndpoint string,
+	keys keyring.Keyring,
+	encCfg encoding.Config,
+	opts *Options,
+	sequences ...Sequence,
+) (*RunResult, error) {
 	opts.Fill()
 	r := rand.New(rand.NewSource(opts.seed))
 
-	conn, err := grpc.Dial(grpcEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	tlsConfig := opts.tlsConfig
+	if opts.tlsCertFile != "" {
+		var err error
+		tlsConfig, err = loadTLSConfig(opts.tlsCertFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the grpc endpoint may be left empty if the endpoints are set in the options
+	addresses := opts.endpoints
+	if grpcEndpoint != "" {
+		addresses = append([]string{grpcEndpoint}, addresses...)
+	}
+	endpoints, err := dialEndpoints(addresses, tlsConfig)
 	if err != nil {
-		return fmt.Errorf("dialing %s: %w", grpcEndpoint, err)
+		return nil, err
 	}
 
 	if opts.suppressLogger {
@@ -49,46 +119,383 @@ func Run(
 		zerolog.SetGlobalLevel(zerolog.Disabled)
 	}
 
+	if opts.logWriter != nil {
+		log.Logger = newLogger(opts.logWriter, opts.logFormat).With().
+			Int64("seed", opts.seed).
+			Str("grpc", addresses[0]).
+			Logger()
+	}
+
 	// Create the account manager to handle account transactions.
-	manager, err := NewAccountManager(ctx, keys, encCfg, opts.masterAcc, conn, opts.pollTime, opts.useFeeGrant)
+	manager, err := NewAccountManager(ctx, keys, encCfg, opts.masterAcc, endpoints.primary(), opts.pollTime, opts.useFeeGrant)
 	if err != nil {
-		return err
+		return nil, errors.Join(err, endpoints.Close())
+	}
+	manager.setEndpoints(endpoints)
+	defer func() {
+		if err := manager.Close(); err != nil {
+			log.Error().Err(err).Msg("failed to close the grpc connections")
+		}
+	}()
+	manager.setRetryPolicy(retryPolicy{maxAttempts: opts.submitAttempts, baseDelay: opts.submitRetryDelay})
+	manager.setSubmitTimeout(opts.submitTimeout)
+	manager.setAccountFunding(uint64(opts.accountFunding))
+	manager.setDryRun(opts.dryRun)
+	manager.setTracing(opts.tracing)
+	manager.setSetupTimeout(opts.setupTimeout)
+	manager.setClock(opts.clock)
+	if opts.pollJitter != 0 {
+		if err := manager.setPollJitter(opts.pollJitter, opts.seed); err != nil {
+			return nil, err
+		}
+	}
+	if opts.gasAdjustment != 0 {
+		if err := manager.setGasAdjustment(opts.gasAdjustment); err != nil {
+			return nil, err
+		}
+	}
+	if opts.confirmations != 0 {
+		if err := manager.setConfirmationDepth(opts.confirmations); err != nil {
+			return nil, err
+		}
+	}
+	if opts.broadcastMode != "" {
+		if err := manager.setBroadcastMode(opts.broadcastMode); err != nil {
+			return nil, err
+		}
+	}
+	if opts.fundingConcurrency != 0 {
+		if err := manager.setFundingConcurrency(opts.fundingConcurrency); err != nil {
+			return nil, err
+		}
+	}
+	if opts.latencyThreshold != 0 {
+		if err := manager.setLatencyThreshold(opts.latencyThreshold); err != nil {
+			return nil, err
+		}
+	}
+	if opts.maxLatencyBreaches < 0 || opts.maxLatencyBreaches > 1 {
+		return nil, fmt.Errorf("max latency breaches must be a fraction between 0 and 1, got %v", opts.maxLatencyBreaches)
+	}
+	if opts.checkSquareSize {
+		if err := manager.setSquareSizeCheck(opts.expectedSquareSize); err != nil {
+			return nil, err
+		}
+	}
+	if opts.accountsFile != "" {
+		if err := manager.setAccountsFile(opts.accountsFile); err != nil {
+			return nil, err
+		}
+	}
+	if err := manager.verifyChainID(opts.chainID); err != nil {
+		return nil, err
+	}
+	if opts.logWriter != nil {
+		log.Logger = log.Logger.With().Str("master", manager.master.Address().String()).Logger()
 	}
 
 	// Initialize each of the sequences by allowing them to allocate accounts.
-	for _, sequence := range sequences {
-		sequence.Init(ctx, manager.conn, manager.AllocateAccounts, r, opts.useFeeGrant)
+	for i, sequence := range sequences {
+		sequence.Init(ctx, manager.conn, manager.SequenceAllocator(i), r, opts.useFeeGrant)
+	}
+
+	if opts.useFeeGrant && opts.feeGrantGranters > 0 {
+		if err := manager.allocateGranters(opts.feeGrantGranters); err != nil {
+			return nil, err
+		}
 	}
 
 	// Generate the allotted accounts on chain by sending them sufficient funds
 	if err := manager.GenerateAccounts(ctx); err != nil {
-		return err
+		return nil, err
+	}
+
+	if opts.refillThreshold > 0 {
+		go manager.autoRefill(ctx, uint64(opts.refillThreshold))
+	}
+
+	if opts.mempoolRPC != "" {
+		mempoolSize, err := rpcMempoolSize(opts.mempoolRPC)
+		if err != nil {
+			return nil, err
+		}
+		if err := manager.setMempoolBackpressure(opts.mempoolHigh, opts.mempoolLow); err != nil {
+			return nil, err
+		}
+		go manager.monitorMempool(ctx, mempoolSize, opts.pollTime)
+	}
+
+	var replay *replayLogger
+	if opts.replayLog != "" {
+		replay, err = newReplayLogger(opts.replayLog, encCfg.Codec)
+		if err != nil {
+			return nil, err
+		}
+		defer replay.Close()
+	}
+
+	stats, finalErr := runSequences(ctx, opts, manager.conn, manager.submit, manager.submitBatch, replay, sequences)
+
+	switch {
+	case opts.refundOnExit && !opts.dryRun:
+		// the run's context may have been cancelled so a new one is used. The
+		// refund revokes the fee grants as well.
+		refundCtx, cancel := context.WithTimeout(context.Background(), refundTimeout)
+		if _, err := manager.Refund(refundCtx); err != nil {
+			log.Error().Err(err).Msg("failed to refund accounts")
+		}
+		cancel()
+	case opts.useFeeGrant && !opts.dryRun:
+		// the run's context may have been cancelled so a new one is used
+		revokeCtx, cancel := context.WithTimeout(context.Background(), revokeTimeout)
+		manager.revokeFeeGrants(revokeCtx)
+		cancel()
+	}
+
+	if err := manager.saveAccounts(); err != nil {
+		log.Error().Err(err).Msg("failed to persist accounts")
+	}
+
+	logSummary(stats)
+	result := newRunResult(stats)
+
+	// a breach of the latency threshold fails the run even if it ended with
+	// the cancellation of the context
+	if err := checkLatencyBreaches(result, opts.maxLatencyBreaches); err != nil {
+		return result, err
+	}
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	if finalErr != nil {
+		return result, finalErr
 	}
 
+	return result, checkSubmitted(result)
+}
+
+// Submitter submits the operations generated by the sequences. The
+// AccountManager is the Submitter used by Run.
+type Submitter interface {
+	Submit(ctx context.Context, op Operation) error
+}
+
+var _ Submitter = &AccountManager{}
+
+// RunSequences runs the sequences concurrently, submitting each of their
+// operations with the submitter, until all sequences have terminated or the
+// context is cancelled. Unlike Run, it doesn't connect to a node or allocate
+// accounts: the sequences must already be initialized and are passed a nil
+// querier. This allows the lifecycle of the sequences to be tested without a
+// node. The returned error follows the same rules as Run.
+func RunSequences(ctx context.Context, submitter Submitter, opts *Options, sequences ...Sequence) (*RunResult, error) {
+	opts.Fill()
+	submit := func(ctx context.Context, op Operation) (submitResult, error) {
+		start := opts.clock.Now()
+		if err := submitter.Submit(ctx, op); err != nil {
+			return submitResult{}, err
+		}
+		return submitResult{latency: opts.clock.Now().Sub(start)}, nil
+	}
+
+	stats, err := runSequences(ctx, opts, nil, submit, nil, nil, sequences)
+	result := newRunResult(stats)
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	if err != nil {
+		return result, err
+	}
+	return result, checkSubmitted(result)
+}
+
+// submitFunc submits an operation and returns the details of the committed
+// transaction.
+type submitFunc func(ctx context.Context, op Operation) (submitResult, error)
+
+// batchSubmitFunc submits a batch of operations and returns the details and
+// error of each, in the same order.
+type batchSubmitFunc func(ctx context.Context, ops []Operation) ([]submitResult, []error)
+
+// runSequences runs each of the sequences concurrently, submitting their
+// operations with submit, until they have all terminated. It returns the stats
+// of each sequence along with the last error of a sequence that failed for a
+// reason other than reaching its end or the context being cancelled.
+func runSequences(
+	ctx context.Context,
+	opts *Options,
+	querier grpc.ClientConn,
+	submit submitFunc,
+	submitBatch batchSubmitFunc,
+	replay *replayLogger,
+	sequences []Sequence,
+) ([]*sequenceStats, error) {
 	errCh := make(chan error, len(sequences))
+	stats := make([]*sequenceStats, len(sequences))
+	budget := newTxBudget(opts.txLimit)
+	// all sequences have terminated by the time runSequences returns so the
+	// remaining commits can be drained
+	notifier := newCommitNotifier(opts.onCommit)
+	defer notifier.close()
 
-	// Spin up a task group to run each of the sequences concurrently.
-	for idx, sequence := range sequences {
-		go func(seqID int, sequence Sequence, errCh chan<- error) {
-			opNum := 0
-			r := rand.New(rand.NewSource(opts.seed))
-			// each sequence loops through the next set of operations, the new messages are then
-			// submitted on chain
-			for {
-				ops, err := sequence.Next(ctx, manager.conn, r)
+	// recordResult records the outcome of an operation. It returns an error if
+	// the operation failed in a way that terminates the sequence.
+	recordResult := func(seqID int, stats *sequenceStats, op Operation, result submitResult, err error) error {
+		if err != nil && op.ExpectRejection && isRejection(result.response) {
+			code := RejectionCode{Codespace: result.response.Codespace, Code: result.response.Code}
+			stats.recordRejection(code)
+			log.Debug().
+				Int("sequence", seqID).
+				Str("codespace", code.Codespace).
+				Uint32("code", code.Code).
+				Str("msgs", msgsToString(op.Msgs)).
+				Msg("invalid tx rejected")
+			return nil
+		}
+		if err != nil {
+			if !isContextErr(err) {
+				stats.recordError()
+				log.Debug().
+					Err(err).
+					Int("sequence", seqID).
+					Str("msgs", msgsToString(op.Msgs)).
+					Msg("tx failed")
+			}
+			return err
+		}
+		if op.ExpectRejection && !opts.dryRun {
+			log.Warn().
+				Int("sequence", seqID).
+				Str("msgs", msgsToString(op.Msgs)).
+				Msg("invalid tx was not rejected")
+		}
+		stats.recordCommit(result.latency)
+		stats.lastNonce = result.nonce
+		if result.breached {
+			stats.recordLatencyBreach()
+		}
+		if result.squareSize != 0 {
+			stats.recordSquareSize(result.squareSize)
+		}
+		if result.response != nil {
+			if opts.recordTxHashes {
+				stats.recordTxHash(result.response.TxHash)
+			}
+			notifier.notify(seqID, result.response.TxHash, result.response.Height)
+		}
+		if err := replay.record(seqID, op, result.nonce); err != nil {
+			log.Error().Err(err).Int("sequence", seqID).Msg("failed to write to replay log")
+		}
+		return nil
+	}
+
+	// runSequence loops through the next set of operations of the sequence
+	// and submits them on chain until an error is encountered. If batching is
+	// enabled, up to the batch size of operations are submitted together.
+	// Submissions that are in flight when the context is cancelled are given
+	// the drain timeout to be committed.
+	runSequence := func(ctx context.Context, seqID int, sequence Sequence, stats *sequenceStats) error {
+		r := rand.New(rand.NewSource(opts.sequenceSeed(seqID)))
+		limiter := newLimiter(opts.rate)
+		batchSize := 1
+		if submitBatch != nil && opts.submitBatchSize > 1 {
+			batchSize = opts.submitBatchSize
+		}
+		submitCtx, cancel := drainContext(ctx, opts.drainTimeout)
+		defer cancel()
+		// recordDrain records whether the operations that were in flight when
+		// the context was cancelled were committed.
+		recordDrain := func(errs ...error) {
+			if ctx.Err() == nil {
+				return
+			}
+			for _, err := range errs {
+				stats.recordDrain(err == nil)
+			}
+		}
+		for {
+			var (
+				batch   = make([]Operation, 0, batchSize)
+				nextErr error
+			)
+			for len(batch) < batchSize {
+				// Stop producing operations once the context is cancelled.
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				// Stop once the total transaction limit across all sequences has been reached.
+				if !budget.take() {
+					nextErr = fmt.Errorf("transaction limit reached: %w", ErrEndOfSequence)
+					break
+				}
+
+				op, err := sequence.Next(ctx, querier, r)
 				if err != nil {
-					errCh <- fmt.Errorf("sequence %d: %w", seqID, err)
-					return
+					// return the unused transaction to the budget for other sequences
+					budget.release()
+					nextErr = err
+					break
 				}
 
-				// Submit the messages to the chain.
-				if err := manager.Submit(ctx, ops); err != nil {
-					errCh <- fmt.Errorf("sequence %d: %w", seqID, err)
-					return
+				// Throttle the submission rate if a limit has been set.
+				if err := waitForRate(ctx, limiter); err != nil {
+					return err
 				}
-				opNum++
+				batch = append(batch, op)
 			}
-		}(idx, sequence, errCh)
+
+			// Submit the messages to the chain.
+			switch {
+			case len(batch) == 1:
+				result, err := submit(submitCtx, batch[0])
+				recordDrain(err)
+				handleResults(ctx, sequence, batch, []submitResult{result}, []error{err})
+				if err := recordResult(seqID, stats, batch[0], result, err); err != nil {
+					return err
+				}
+			case len(batch) > 1:
+				results, errs := submitBatch(submitCtx, batch)
+				recordDrain(errs...)
+				handleResults(ctx, sequence, batch, results, errs)
+				var batchErr error
+				for i, op := range batch {
+					if err := recordResult(seqID, stats, op, results[i], errs[i]); err != nil && batchErr == nil {
+						batchErr = err
+					}
+				}
+				if batchErr != nil {
+					return batchErr
+				}
+			}
+
+			if nextErr != nil {
+				return nextErr
+			}
+		}
+	}
+
+	// Spin up a task group to run each of the sequences concurrently.
+	for idx, sequence := range sequences {
+		stats[idx] = &sequenceStats{}
+		go func(seqID int, sequence Sequence, stats *sequenceStats, errCh chan<- error) {
+			var err error
+			defer func() {
+				// recover from panics in the sequence so that the other
+				// sequences can continue to run
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%w: %v\n%s", ErrSequencePanic, r, debug.Stack())
+				}
+				stats.lastErr = err
+				errCh <- fmt.Errorf("sequence %d: %w", seqID, err)
+			}()
+			seqCtx, cancel := opts.sequenceControl.start(ctx, seqID)
+			defer cancel()
+			err = runSequence(seqCtx, seqID, sequence, stats)
+			// the error of an abandoned transaction is ignored
+			if ctx.Err() == nil && opts.sequenceControl.isStopped(seqID) {
+				err = fmt.Errorf("stopped: %w", ErrEndOfSequence)
+			}
+		}(idx, sequence, stats[idx], errCh)
 	}
 
 	var finalErr error
@@ -101,26 +508,120 @@ func Run(
 			log.Info().Err(err).Msg("sequence terminated")
 			continue
 		}
-		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		if isContextErr(err) {
 			continue
 		}
 		log.Error().Err(err).Msg("sequence failed")
 		finalErr = err
 	}
 
-	if ctx.Err() != nil {
-		return ctx.Err()
+	return stats, finalErr
+}
+
+// checkLatencyBreaches returns ErrLatencyThreshold if the fraction of committed
+// transactions that exceeded the latency threshold is larger than maxFraction.
+// A maxFraction of zero disables the check.
+func checkLatencyBreaches(result *RunResult, maxFraction float64) error {
+	if maxFraction <= 0 || result.Committed == 0 {
+		return nil
+	}
+	fraction := float64(result.LatencyBreaches) / float64(result.Committed)
+	if fraction > maxFraction {
+		return fmt.Errorf("%w: %d of %d transactions (%.2f%%), at most %.2f%% allowed",
+			ErrLatencyThreshold, result.LatencyBreaches, result.Committed, fraction*100, maxFraction*100)
+	}
+	return nil
+}
+
+// checkSubmitted returns ErrNoOperations and logs a warning if none of the
+// sequences submitted an operation. A run without sequences is not an error.
+func checkSubmitted(result *RunResult) error {
+	if result.Submitted > 0 || len(result.Sequences) == 0 {
+		return nil
+	}
+	log.Warn().
+		Int("sequences", len(result.Sequences)).
+		Msg("all sequences ended without submitting any operations, check their configuration")
+	return fmt.Errorf("%w by %d sequences", ErrNoOperations, len(result.Sequences))
+}
+
+// drainContext returns a context for submissions that is cancelled the grace
+// period after ctx so that submissions in flight when ctx is cancelled can still
+// be committed. Without a grace period, ctx is returned.
+func drainContext(ctx context.Context, grace time.Duration) (context.Context, context.CancelFunc) {
+	if grace <= 0 {
+		return ctx, func() {}
 	}
+	drainCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-drainCtx.Done():
+		}
+		cancel()
+	})
+	return drainCtx, func() {
+		stop()
+		cancel()
+	}
+}
 
-	return finalErr
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
 }
 
 type Options struct {
-	seed           int64
-	masterAcc      string
-	pollTime       time.Duration
-	useFeeGrant    bool
-	suppressLogger bool
+	seed             int64
+	seedStrategy     SeedStrategy
+	masterAcc        string
+	chainID          string
+	pollTime         time.Duration
+	useFeeGrant      bool
+	feeGrantGranters int
+	suppressLogger   bool
+	logWriter        io.Writer
+	logFormat        LogFormat
+	rate             int
+	endpoints        []string
+
+	submitAttempts   int
+	submitRetryDelay time.Duration
+	submitTimeout    time.Duration
+	submitBatchSize  int
+	txLimit          int
+
+	tlsConfig   *tls.Config
+	tlsCertFile string
+	replayLog   string
+
+	accountFunding  int64
+	refillThreshold int64
+	dryRun          bool
+	tracing         bool
+	accountsFile    string
+	refundOnExit    bool
+	pollJitter      float64
+	setupTimeout    time.Duration
+	gasAdjustment   float64
+	confirmations   int
+	recordTxHashes  bool
+	onCommit        OnCommitFunc
+	sequenceControl *SequenceControl
+
+	mempoolRPC  string
+	mempoolHigh int
+	mempoolLow  int
+
+	checkSquareSize    bool
+	expectedSquareSize int
+	drainTimeout       time.Duration
+	broadcastMode      BroadcastMode
+	latencyThreshold   time.Duration
+	maxLatencyBreaches float64
+	fundingConcurrency int
+	clock              Clock
 }
 
 func (o *Options) Fill() {
@@ -130,6 +631,9 @@ func (o *Options) Fill() {
 	if o.pollTime == 0 {
 		o.pollTime = user.DefaultPollTime
 	}
+	if o.clock == nil {
+		o.clock = realClock{}
+	}
 }
 
 func DefaultOptions() *Options {
@@ -138,16 +642,45 @@ func DefaultOptions() *Options {
 	return opts
 }
 
+// WithLogger writes the logs to w in the given format. Each line includes the
+// seed, the master account and the grpc endpoint so that the output of several
+// clients can be told apart. By default, the global zerolog logger is used.
+func (o *Options) WithLogger(w io.Writer, format LogFormat) *Options {
+	o.logWriter = w
+	o.logFormat = format
+	return o
+}
+
+// WithJSONLogs writes the logs as JSON lines to stdout.
+func (o *Options) WithJSONLogs() *Options {
+	return o.WithLogger(os.Stdout, JSONLogFormat)
+}
+
 func (o *Options) SuppressLogs() *Options {
 	o.suppressLogger = true
 	return o
 }
 
+// UseFeeGrant has the master account, or the granters if set, pay the fees of
+// the sequence accounts through fee allowances. The declared fees are unchanged
+// so the load competes in the mempool like any other unless the node lowers the
+// priority of fee grant transactions with the --fee-grant-priority flag.
 func (o *Options) UseFeeGrant() *Options {
 	o.useFeeGrant = true
 	return o
 }
 
+// WithFeeGrantGranters allocates n granter accounts which, in place of the
+// master account, grant the fee allowances of the sequence accounts in a
+// round-robin fashion. This spreads the fees and the grant transactions across
+// several accounts when there are many sequences. The granters are funded with
+// an even split of the master account's unallocated balance. It has no effect
+// unless UseFeeGrant is set.
+func (o *Options) WithFeeGrantGranters(n int) *Options {
+	o.feeGrantGranters = n
+	return o
+}
+
 func (o *Options) SpecifyMasterAccount(name string) *Options {
 	o.masterAcc = name
 	return o
@@ -158,7 +691,375 @@ func (o *Options) WithSeed(seed int64) *Options {
 	return o
 }
 
+// WithSeedStrategy sets whether sequences share the same seed or each derive
+// their own. See SeedStrategy.
+func (o *Options) WithSeedStrategy(strategy SeedStrategy) *Options {
+	o.seedStrategy = strategy
+	return o
+}
+
+// sequenceSeed returns the seed for the random source of the sequence.
+func (o *Options) sequenceSeed(seqID int) int64 {
+	if o.seedStrategy == SharedSeed {
+		return o.seed
+	}
+	return o.seed + int64(seqID)
+}
+
 func (o *Options) WithPollTime(pollTime time.Duration) *Options {
 	o.pollTime = pollTime
 	return o
 }
+
+// WithPollJitter randomizes the poll time of each sequence within ±frac of the
+// base poll time, i.e. a frac of 0.2 with a poll time of 1s results in poll times
+// between 800ms and 1.2s. This avoids many cloned sequences querying the node in
+// synchronized bursts. The jitter is drawn from the seeded random source so that
+// it is deterministic. frac must be in the range [0, 1). By default, there is no
+// jitter.
+func (o *Options) WithPollJitter(frac float64) *Options {
+	o.pollJitter = frac
+	return o
+}
+
+// WithGasAdjustment simulates the gas of each operation that doesn't set a gas
+// limit and multiplies the estimate by factor, like the --gas-adjustment flag
+// of the SDK's CLI. This leaves headroom for estimates that are too tight
+// under load. factor must be at least 1.0, which uses the estimate as is. By
+// default, gas isn't simulated and such operations use DefaultGasLimit.
+func (o *Options) WithGasAdjustment(factor float64) *Options {
+	o.gasAdjustment = factor
+	return o
+}
+
+// WithRate throttles each sequence to submit at most txPerSecond transactions
+// per second. The rate is applied per sequence so N cloned sequences will
+// submit at N times the rate. A rate of zero means no throttling.
+func (o *Options) WithRate(txPerSecond int) *Options {
+	o.rate = txPerSecond
+	return o
+}
+
+// WithEndpoints adds additional grpc endpoints to the one provided to Run.
+// Transactions are distributed across all endpoints in a round-robin fashion.
+// Endpoints that become unreachable are skipped for a backoff period.
+func (o *Options) WithEndpoints(endpoints ...string) *Options {
+	o.endpoints = append(o.endpoints, endpoints...)
+	return o
+}
+
+// WithSubmitRetry retries broadcasts that fail with a transient error such
+// as an unreachable node or a full mempool up to maxAttempts times in total.
+// The delay between attempts starts at baseDelay and doubles after each
+// attempt. Permanent errors are returned immediately. Once a transaction has
+// been broadcast it is never submitted again. By default submissions are not
+// retried.
+func (o *Options) WithSubmitRetry(maxAttempts int, baseDelay time.Duration) *Options {
+	o.submitAttempts = maxAttempts
+	o.submitRetryDelay = baseDelay
+	return o
+}
+
+// WithSubmitTimeout bounds the time each attempt to broadcast a transaction
+// can take and, separately, the time waiting for it to be committed. Exceeding
+// it returns ErrSubmitTimeout, which isn't retried as the transaction may still
+// be committed. By default there is no timeout.
+func (o *Options) WithSubmitTimeout(timeout time.Duration) *Options {
+	o.submitTimeout = timeout
+	return o
+}
+
+// WithSubmitBatchSize has each sequence broadcast up to n of its operations
+// before waiting for any of them to be committed, after which it waits for all
+// of them concurrently. This raises the throughput of each sequence as it no
+// longer waits for a block between transactions. The operations of a batch are
+// not retried beyond resyncing a drifted sequence number and are always sent to
+// the same endpoint. A batch size of one or less submits each operation on its
+// own, which is the default.
+func (o *Options) WithSubmitBatchSize(n int) *Options {
+	o.submitBatchSize = n
+	return o
+}
+
+// WithConfirmationDepth waits until the block including each transaction has
+// been confirmed by n blocks, counting itself, before the operation is
+// complete. The recorded latency includes the wait. This tolerates reorgs on
+// networks without instant finality. A depth of zero or one completes the
+// operation once the transaction is included, which is the default. n must not
+// be negative.
+func (o *Options) WithConfirmationDepth(n int) *Options {
+	o.confirmations = n
+	return o
+}
+
+// WithSetupTimeout bounds the time taken to fund the accounts allocated by the
+// sequences before any of them start. Exceeding it returns ErrSetupTimeout
+// along with the number of accounts that were funded. By default there is no
+// timeout.
+func (o *Options) WithSetupTimeout(timeout time.Duration) *Options {
+	o.setupTimeout = timeout
+	return o
+}
+
+// WithMempoolBackpressure queries the number of unconfirmed transactions from
+// the node's RPC endpoint every poll time. Once it reaches the high-water mark,
+// each sequence pauses before its next submission until the mempool drains to
+// the low-water mark. This models a well-behaved client that avoids flooding a
+// full mempool. The marks must satisfy 0 <= low < high.
+func (o *Options) WithMempoolBackpressure(rpcEndpoint string, high, low int) *Options {
+	o.mempoolRPC = rpcEndpoint
+	o.mempoolHigh = high
+	o.mempoolLow = low
+	return o
+}
+
+// WithTxLimit stops the client after n transactions have been submitted across
+// all sequences. Transactions that are in flight when the limit is reached are
+// still committed. A limit of zero means no limit.
+func (o *Options) WithTxLimit(n int) *Options {
+	o.txLimit = n
+	return o
+}
+
+// WithTLS secures the connection to each grpc endpoint using the provided TLS
+// configuration. Client certificates can be included for mutual TLS. If the
+// server name is not set, it defaults to the host of each endpoint, otherwise
+// it must match the endpoint host. Connections are insecure by default.
+func (o *Options) WithTLS(config *tls.Config) *Options {
+	o.tlsConfig = config
+	return o
+}
+
+// WithTLSFromCertFile secures the connection to each grpc endpoint, trusting
+// the PEM encoded certificates in the provided file.
+func (o *Options) WithTLSFromCertFile(path string) *Options {
+	o.tlsCertFile = path
+	return o
+}
+
+// WithReplayLog writes every committed operation to the file at path as
+// newline delimited JSON. The log can be replayed using the ReplaySequence.
+func (o *Options) WithReplayLog(path string) *Options {
+	o.replayLog = path
+	return o
+}
+
+// WithAccountFunding sets the minimum amount of utia that each account allocated
+// by the sequences is funded with. Sequences that request a larger balance are
+// funded with the requested amount.
+func (o *Options) WithAccountFunding(amount int64) *Options {
+	o.accountFunding = amount
+	return o
+}
+
+// WithAutoRefill periodically checks the balance of each account allocated by the
+// sequences and, for all accounts whose balance is below the threshold, sends a
+// single transaction from the master account topping them back up to their initial
+// balance.
+func (o *Options) WithAutoRefill(threshold int64) *Options {
+	o.refillThreshold = threshold
+	return o
+}
+
+// DryRun simulates each transaction, logging the estimated gas, instead of
+// broadcasting it. Accounts are never funded and height delays are skipped.
+// Sequences that depend on committed state may therefore fail to simulate;
+// these failures are logged but don't terminate the sequence. This is useful
+// for checking that a sequence generates well-formed messages.
+func (o *Options) DryRun() *Options {
+	o.dryRun = true
+	return o
+}
+
+// WithChainID sets the chain id that the client expects to be running against.
+// Run fails early if the node reports a different chain id, rather than every
+// transaction failing signature verification. By default, the chain id is
+// detected from the node.
+func (o *Options) WithChainID(chainID string) *Options {
+	o.chainID = chainID
+	return o
+}
+
+// WithTracing attaches a generated trace id to each submission as the
+// x-txsim-trace-id gRPC metadata header and logs it alongside the tx hash. This
+// allows submissions to be correlated with traces collected on the node.
+func (o *Options) WithTracing() *Options {
+	o.tracing = true
+	return o
+}
+
+// WithTxHashes records the hash of each committed transaction in the
+// SequenceResult of its sequence so that tooling can verify their inclusion on
+// chain after the run. Each hash is also logged at debug level. Recording is off
+// by default as the hashes grow without bound over long runs.
+func (o *Options) WithTxHashes() *Options {
+	o.recordTxHashes = true
+	return o
+}
+
+// WithOnCommit calls onCommit with the hash and height of each committed
+// transaction along with the id of the sequence that submitted it. This allows
+// external tooling to react to each transaction, i.e. to assert invariants. The
+// callback is invoked from a single goroutine in the order the transactions were
+// committed so it doesn't need to be concurrently safe. It doesn't block the
+// sequences unless it falls more than 1024 commits behind. It is not called in
+// dry runs.
+func (o *Options) WithOnCommit(onCommit OnCommitFunc) *Options {
+	o.onCommit = onCommit
+	return o
+}
+
+// WithFundingConcurrency splits the funding of the accounts across n
+// transactions and sets up their signers with n workers, which speeds up the
+// setup of thousands of accounts. As all funding transactions are signed by the
+// master account, they are broadcast one after the other in the order of its
+// sequence and only confirmed concurrently. By default, all accounts are funded
+// by a single transaction and set up one after the other.
+func (o *Options) WithFundingConcurrency(n int) *Options {
+	o.fundingConcurrency = n
+	return o
+}
+
+// WithLatencyThreshold logs a warning for every transaction whose commit
+// latency exceeds threshold. The number of such transactions is reported in
+// the RunResult. See WithMaxLatencyBreaches to fail the run on breaches.
+func (o *Options) WithLatencyThreshold(threshold time.Duration) *Options {
+	o.latencyThreshold = threshold
+	return o
+}
+
+// WithMaxLatencyBreaches fails the run with ErrLatencyThreshold if more than
+// the fraction, between 0 and 1, of committed transactions exceeded the latency
+// threshold set by WithLatencyThreshold. This allows txsim to be used as a
+// latency regression gate. A fraction of zero, the default, never fails the
+// run.
+func (o *Options) WithMaxLatencyBreaches(fraction float64) *Options {
+	o.maxLatencyBreaches = fraction
+	return o
+}
+
+// WithClock measures the commit latency of transactions with clock instead of
+// the wall clock so that tests can assert exact latencies.
+func (o *Options) WithClock(clock Clock) *Options {
+	o.clock = clock
+	return o
+}
+
+// WithBroadcastMode sets the mode in which transactions are broadcast. See
+// BroadcastMode for the tradeoffs between throughput and latency of each mode.
+// The default is SyncBroadcastMode.
+func (o *Options) WithBroadcastMode(mode BroadcastMode) *Options {
+	o.broadcastMode = mode
+	return o
+}
+
+// WithDrainTimeout waits up to timeout, once the context of the run is
+// cancelled, for the submissions in flight to be committed instead of
+// abandoning them. No further operations are produced in the meantime. The
+// number of drained and abandoned operations is reported in the RunResult. By
+// default, submissions in flight are abandoned.
+func (o *Options) WithDrainTimeout(timeout time.Duration) *Options {
+	o.drainTimeout = timeout
+	return o
+}
+
+// WithSquareSizeCheck queries the square size of the block that includes each
+// committed PFB and records it in the RunResult. If expected is positive, a
+// warning is logged for every block with a different square size. Each block
+// is queried once. By default, square sizes are not checked.
+func (o *Options) WithSquareSizeCheck(expected int) *Options {
+	o.checkSquareSize = true
+	o.expectedSquareSize = expected
+	return o
+}
+
+// WithSequenceControl lets the caller stop individual sequences of the run
+// through the control while the others continue.
+func (o *Options) WithSequenceControl(control *SequenceControl) *Options {
+	o.sequenceControl = control
+	return o
+}
+
+// WithAccountsFile persists the name, address and sequence of each allocated
+// account to the file at path when the run ends and reloads them at the start of
+// the next run. Persisted accounts that are allocated again are reused and only
+// topped up to their balance instead of being funded from scratch. Their
+// signers start from the larger of the persisted sequence and the sequence on
+// chain. Reusing accounts requires a
+// keyring that is persisted between runs, i.e. one loaded from a key path.
+func (o *Options) WithAccountsFile(path string) *Options {
+	o.accountsFile = path
+	return o
+}
+
+// WithRefundOnExit sweeps the remaining balance of each account allocated by
+// the sequences, minus the fee, back to the master account when the run ends.
+// Any fee grants are revoked beforehand. This is ignored in dry runs.
+func (o *Options) WithRefundOnExit() *Options {
+	o.refundOnExit = true
+	return o
+}
+
+// newLimiter returns a token bucket limiter for the given rate. It returns nil
+// if the rate is not positive.
+func newLimiter(txPerSecond int) *rate.Limiter {
+	if txPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(txPerSecond), 1)
+}
+
+// waitForRate blocks until the limiter permits another submission or the
+// context is cancelled. A nil limiter never blocks.
+func waitForRate(ctx context.Context, limiter *rate.Limiter) error {
+	if limiter == nil {
+		return nil
+	}
+	reservation := limiter.Reserve()
+	if err := sleep(ctx, reservation.Delay()); err != nil {
+		reservation.Cancel()
+		return err
+	}
+	return nil
+}
+
+// txBudget is a concurrently safe count of the transactions that can still be
+// submitted across all sequences. A nil budget is unlimited.
+type txBudget struct {
+	remaining atomic.Int64
+}
+
+func newTxBudget(n int) *txBudget {
+	if n <= 0 {
+		return nil
+	}
+	b := &txBudget{}
+	b.remaining.Store(int64(n))
+	return b
+}
+
+// take reserves a single transaction from the budget. It returns false if the
+// budget has been exhausted, in which case the budget is left unchanged so
+// that a later release makes a transaction available again.
+func (b *txBudget) take() bool {
+	if b == nil {
+		return true
+	}
+	for {
+		remaining := b.remaining.Load()
+		if remaining <= 0 {
+			return false
+		}
+		if b.remaining.CompareAndSwap(remaining, remaining-1) {
+			return true
+		}
+	}
+}
+
+// release returns a previously taken transaction to the budget.
+func (b *txBudget) release() {
+	if b == nil {
+		return
+	}
+	b.remaining.Add(1)
+}
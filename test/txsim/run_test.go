@@ -6,7 +6,10 @@ package txsim_test
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,10 +21,14 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	blob "github.com/celestiaorg/celestia-app/v2/x/blob/types"
+	vesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	bank "github.com/cosmos/cosmos-sdk/x/bank/types"
 	distribution "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/rpc/client/http"
 )
 
 func TestTxSimulator(t *testing.T) {
@@ -34,6 +41,7 @@ func TestTxSimulator(t *testing.T) {
 		sequences   []txsim.Sequence
 		expMessages map[string]int64
 		useFeegrant bool
+		granters    int
 	}{
 		{
 			name:      "send sequence",
@@ -41,6 +49,17 @@ func TestTxSimulator(t *testing.T) {
 			// we expect at least 5 bank send messages within 30 seconds
 			expMessages: map[string]int64{sdk.MsgTypeURL(&bank.MsgSend{}): 5},
 		},
+		{
+			name:        "multisend sequence",
+			sequences:   []txsim.Sequence{txsim.NewMultiSendSequence(5, 100, 100)},
+			expMessages: map[string]int64{sdk.MsgTypeURL(&bank.MsgMultiSend{}): 5},
+		},
+		{
+			name:      "multi signer sequence",
+			sequences: []txsim.Sequence{txsim.NewMultiSignerSequence(3, 100, 100)},
+			// each transaction contains a send from each of the 3 signers
+			expMessages: map[string]int64{sdk.MsgTypeURL(&bank.MsgSend{}): 15},
+		},
 		{
 			name:      "stake sequence",
 			sequences: []txsim.Sequence{txsim.NewStakeSequence(1000)},
@@ -51,6 +70,15 @@ func TestTxSimulator(t *testing.T) {
 				// testnet has only one validator, this never happens
 			},
 		},
+		{
+			name:      "staking sequence",
+			sequences: []txsim.Sequence{txsim.NewStakingSequence(2, 10000)},
+			expMessages: map[string]int64{
+				sdk.MsgTypeURL(&staking.MsgDelegate{}): 2,
+				// NOTE: this sequence also makes redelegations but because the
+				// testnet has only one validator, this never happens
+			},
+		},
 		{
 			name: "blob sequence",
 			sequences: []txsim.Sequence{
@@ -95,6 +123,23 @@ func TestTxSimulator(t *testing.T) {
 			},
 			useFeegrant: true,
 		},
+		{
+			name: "multi mixed sequence using a pool of fee granters",
+			sequences: append(append(
+				txsim.NewSendSequence(2, 1000, 100).Clone(3),
+				txsim.NewStakeSequence(1000).Clone(3)...),
+				txsim.NewBlobSequence(txsim.NewRange(1000, 1000), txsim.NewRange(1, 3)).Clone(3)...),
+			expMessages: map[string]int64{
+				sdk.MsgTypeURL(&bank.MsgSend{}):                            15,
+				sdk.MsgTypeURL(&staking.MsgDelegate{}):                     2,
+				sdk.MsgTypeURL(&distribution.MsgWithdrawDelegatorReward{}): 10,
+				sdk.MsgTypeURL(&blob.MsgPayForBlobs{}):                     10,
+				// each of the 12 sequence accounts has its allowance revoked
+				sdk.MsgTypeURL(&feegrant.MsgRevokeAllowance{}): 12,
+			},
+			useFeegrant: true,
+			granters:    3,
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -107,7 +152,7 @@ func TestTxSimulator(t *testing.T) {
 				SuppressLogs().
 				WithPollTime(time.Millisecond * 100)
 			if tc.useFeegrant {
-				opts.UseFeeGrant()
+				opts.UseFeeGrant().WithFeeGrantGranters(tc.granters)
 			}
 
 			err := txsim.Run(
@@ -141,6 +186,102 @@ func TestTxSimulator(t *testing.T) {
 	}
 }
 
+func TestVestingSequence(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestVestingSequence in short mode.")
+	}
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	keyring, rpcAddr, grpcAddr := Setup(t)
+	opts := txsim.DefaultOptions().
+		SuppressLogs().
+		WithPollTime(time.Millisecond * 100)
+
+	// the sequences end once all vesting accounts have been created
+	err := txsim.Run(ctx, grpcAddr, keyring, encCfg, opts, txsim.NewVestingSequence(5, 1000, time.Hour).Clone(2)...)
+	require.NoError(t, err)
+
+	blocks, err := testnode.ReadBlockchain(context.Background(), rpcAddr)
+	require.NoError(t, err)
+	created := 0
+	for _, block := range blocks {
+		msgs, err := testnode.DecodeBlockData(block.Data)
+		require.NoError(t, err, block.Height)
+		for _, msg := range msgs {
+			if _, ok := msg.(*vesting.MsgCreateVestingAccount); ok {
+				created++
+			}
+		}
+	}
+	require.Equal(t, 10, created)
+}
+
+func TestRunRecordsTxHashes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping TestRunRecordsTxHashes in short mode.")
+	}
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	keyring, rpcAddr, grpcAddr := Setup(t)
+	var committed []string
+	opts := txsim.DefaultOptions().
+		SuppressLogs().
+		WithPollTime(time.Millisecond * 100).
+		WithTxHashes().
+		WithOnCommit(func(seqID int, txHash []byte, height int64) {
+			assert.Zero(t, seqID)
+			assert.Positive(t, height)
+			committed = append(committed, strings.ToUpper(hex.EncodeToString(txHash)))
+		})
+
+	result, err := txsim.RunWithResult(ctx, grpcAddr, keyring, encCfg, opts, txsim.NewSendSequence(2, 1000, 100))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, result.Sequences, 1)
+	hashes := result.Sequences[0].TxHashes
+	require.NotEmpty(t, hashes)
+	require.Len(t, hashes, result.Sequences[0].Committed)
+	// the callback has been called for every commit once the run returns
+	require.Equal(t, hashes, committed)
+
+	client, err := http.New(rpcAddr, "/websocket")
+	require.NoError(t, err)
+	for _, hash := range hashes {
+		bz, err := hex.DecodeString(hash)
+		require.NoError(t, err)
+		res, err := client.Tx(context.Background(), bz, false)
+		require.NoError(t, err, hash)
+		require.Zero(t, res.TxResult.Code, hash)
+	}
+}
+
+// BenchmarkSubmitBatchSize compares the throughput of submitting each
+// transaction on its own with broadcasting them in batches. The reported tx/s
+// includes the setup of the accounts.
+func BenchmarkSubmitBatchSize(b *testing.B) {
+	encCfg := encoding.MakeConfig(app.ModuleEncodingRegisters...)
+	keyring, _, grpcAddr := Setup(b)
+
+	for _, batchSize := range []int{1, 10, 50} {
+		b.Run(fmt.Sprintf("batch size %d", batchSize), func(b *testing.B) {
+			opts := txsim.DefaultOptions().
+				SuppressLogs().
+				WithPollTime(time.Millisecond * 100).
+				WithTxLimit(b.N).
+				WithSubmitBatchSize(batchSize)
+
+			start := time.Now()
+			result, err := txsim.RunWithResult(context.Background(), grpcAddr, keyring, encCfg, opts, txsim.NewMultiSendSequence(2, 100, b.N))
+			require.NoError(b, err)
+			require.Equal(b, b.N, result.Committed)
+			b.ReportMetric(float64(result.Committed)/time.Since(start).Seconds(), "tx/s")
+		})
+	}
+}
+
 func Setup(t testing.TB) (keyring.Keyring, string, string) {
 	t.Helper()
 
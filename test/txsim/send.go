@@ -27,6 +27,7 @@ type SendSequence struct {
 	accounts       []types.AccAddress
 	index          int
 	numIterations  int
+	gas            fixedGas
 }
 
 func NewSendSequence(numAccounts, sendAmount, numIterations int) *SendSequence {
@@ -38,10 +39,18 @@ func NewSendSequence(numAccounts, sendAmount, numIterations int) *SendSequence {
 	}
 }
 
+// WithGas pins the gas limit and gas price of every send. Next fails if the gas
+// limit is below the SendGasLimit. A zero gas price uses the default minimum gas
+// price. The accounts are funded to cover the fixed fee.
+func (s *SendSequence) WithGas(limit uint64, price float64) *SendSequence {
+	s.gas = fixedGas{limit: limit, price: price}
+	return s
+}
+
 func (s *SendSequence) Clone(n int) []Sequence {
 	sequenceGroup := make([]Sequence, n)
 	for i := 0; i < n; i++ {
-		sequenceGroup[i] = NewSendSequence(s.numAccounts, s.sendAmount, s.numIterations)
+		sequenceGroup[i] = NewSendSequence(s.numAccounts, s.sendAmount, s.numIterations).WithGas(s.gas.limit, s.gas.price)
 	}
 	return sequenceGroup
 }
@@ -49,7 +58,7 @@ func (s *SendSequence) Clone(n int) []Sequence {
 // Init sets up the accounts involved in the sequence. It calculates the necessary balance as the fees per transaction
 // multiplied by the number of expected iterations plus the amount to be sent from one account to another
 func (s *SendSequence) Init(_ context.Context, _ grpc.ClientConn, allocateAccounts AccountAllocator, _ *rand.Rand, _ bool) {
-	amount := s.sendAmount + (s.numIterations * int(sendFee))
+	amount := s.sendAmount + (s.numIterations * int(s.gas.fee(SendGasLimit)))
 	s.accounts = allocateAccounts(s.numAccounts, amount)
 }
 
@@ -65,6 +74,9 @@ func (s *SendSequence) Next(_ context.Context, _ grpc.ClientConn, rand *rand.Ran
 		Delay:    uint64(rand.Int63n(int64(s.maxHeightDelay))),
 		GasLimit: SendGasLimit,
 	}
+	if err := s.gas.apply(&op); err != nil {
+		return Operation{}, err
+	}
 	s.index++
 	return op, nil
 }
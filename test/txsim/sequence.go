@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math/rand"
 
+	"github.com/celestiaorg/celestia-app/v2/pkg/user"
 	"github.com/celestiaorg/go-square/blob"
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/gogo/protobuf/grpc"
@@ -39,6 +40,18 @@ type Operation struct {
 	Delay    uint64
 	GasLimit uint64
 	GasPrice float64
+	// Memo is attached to the transaction. It must not exceed the chain's
+	// maximum memo length.
+	Memo string
+	// ExpectRejection marks the operation as intentionally invalid. If it is
+	// rejected, the code is recorded instead of terminating the sequence.
+	ExpectRejection bool
+
+	// corruptSignature invalidates the signature of the transaction
+	corruptSignature bool
+	// cosigners are the signers of the messages other than the first one,
+	// which pays the fee. They are set when the operation is prepared.
+	cosigners []*user.Signer
 }
 
 const (
@@ -0,0 +1,92 @@
+package txsim
+
+import (
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/go-square/inclusion"
+)
+
+const (
+	// pfbBaseBytes is an upper estimate of the size of a signed PFB
+	// transaction without any blobs
+	pfbBaseBytes = 400
+	// pfbBytesPerBlob is an upper estimate of the size the PFB transaction
+	// grows by for each blob (namespace, size, commitment and share version)
+	pfbBytesPerBlob = 80
+)
+
+// squareBlobSizes returns the sizes of numBlobs blobs that, together with the
+// PFB transaction paying for them, fill a data square of the given size as
+// tightly as possible. Blobs are laid out following the same alignment rules
+// as the square construction. The sizes are deterministic so that the same
+// square is produced each time. If not even a single byte per blob fits, the
+// returned sizes are all one.
+func squareBlobSizes(squareSize, numBlobs int) []int {
+	totalShares := squareSize * squareSize
+	start := pfbShares(numBlobs)
+	threshold := appconsts.SubtreeRootThreshold(appconsts.LatestVersion)
+
+	// evenly split the remaining shares amongst the blobs
+	shareCounts := make([]int, numBlobs)
+	for i := range shareCounts {
+		shareCounts[i] = max((totalShares-start)/numBlobs, 1)
+	}
+
+	// shrink the blobs, largest first, until the padding required to align
+	// them fits in the square
+	for blobsEnd(start, shareCounts, threshold) > totalShares {
+		largest := 0
+		for i, count := range shareCounts {
+			if count > shareCounts[largest] {
+				largest = i
+			}
+		}
+		if shareCounts[largest] == 1 {
+			break
+		}
+		shareCounts[largest]--
+	}
+
+	// grow the blobs, in order, while they still fit in the square
+	for i := range shareCounts {
+		for {
+			shareCounts[i]++
+			if blobsEnd(start, shareCounts, threshold) > totalShares {
+				shareCounts[i]--
+				break
+			}
+		}
+	}
+
+	sizes := make([]int, numBlobs)
+	for i, count := range shareCounts {
+		sizes[i] = sparseSharesCapacity(count)
+	}
+	return sizes
+}
+
+// blobsEnd returns the index of the share after the last blob, starting at
+// the provided share index, when aligned according to the subtree root threshold.
+func blobsEnd(start int, shareCounts []int, threshold int) int {
+	cursor := start
+	for _, count := range shareCounts {
+		cursor = inclusion.NextShareIndex(cursor, count, threshold) + count
+	}
+	return cursor
+}
+
+// pfbShares returns the number of compact shares reserved for a PFB
+// transaction paying for numBlobs blobs.
+func pfbShares(numBlobs int) int {
+	txBytes := pfbBaseBytes + numBlobs*pfbBytesPerBlob
+	if txBytes <= appconsts.FirstCompactShareContentSize {
+		return 1
+	}
+	remaining := txBytes - appconsts.FirstCompactShareContentSize
+	return 1 + (remaining+appconsts.ContinuationCompactShareContentSize-1)/appconsts.ContinuationCompactShareContentSize
+}
+
+// sparseSharesCapacity returns the number of bytes of a blob that exactly
+// fills the provided number of shares.
+func sparseSharesCapacity(shares int) int {
+	return appconsts.FirstSparseShareContentSize + (shares-1)*appconsts.ContinuationSparseShareContentSize
+}
@@ -0,0 +1,47 @@
+package txsim
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/celestiaorg/go-square/shares"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSquareBlobSizes(t *testing.T) {
+	threshold := appconsts.SubtreeRootThreshold(appconsts.LatestVersion)
+	for _, squareSize := range []int{2, 8, 32, 128} {
+		for _, numBlobs := range []int{1, 3, 10} {
+			t.Run(fmt.Sprintf("square %d blobs %d", squareSize, numBlobs), func(t *testing.T) {
+				sizes := squareBlobSizes(squareSize, numBlobs)
+				require.Len(t, sizes, numBlobs)
+
+				shareCounts := make([]int, numBlobs)
+				for i, size := range sizes {
+					shareCounts[i] = shares.SparseSharesNeeded(uint32(size))
+					// each blob exactly fills its shares
+					require.Equal(t, sparseSharesCapacity(shareCounts[i]), size)
+				}
+
+				totalShares := squareSize * squareSize
+				start := pfbShares(numBlobs)
+				if start+numBlobs > totalShares {
+					return
+				}
+				require.LessOrEqual(t, blobsEnd(start, shareCounts, threshold), totalShares)
+
+				// no blob can grow by another share and still fit
+				for i := range shareCounts {
+					shareCounts[i]++
+					require.Greater(t, blobsEnd(start, shareCounts, threshold), totalShares)
+					shareCounts[i]--
+				}
+			})
+		}
+	}
+}
+
+func TestSquareBlobSizesDeterministic(t *testing.T) {
+	require.Equal(t, squareBlobSizes(64, 4), squareBlobSizes(64, 4))
+}
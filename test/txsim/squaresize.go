@@ -0,0 +1,110 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client/grpc/tmservice"
+	"github.com/rs/zerolog/log"
+)
+
+// squareSizeCacheHeights is the number of heights below the latest for which
+// the square size is cached.
+const squareSizeCacheHeights = 16
+
+// squareSizeFunc returns the square size of the block at the height.
+type squareSizeFunc func(ctx context.Context, height int64) (uint64, error)
+
+// squareSizeCheck looks up the square size of the blocks that include PFBs and
+// compares it to the expected size, if any. The sizes are cached as several
+// sequences commit to the same blocks. A nil check does nothing.
+type squareSizeCheck struct {
+	expected   uint64
+	squareSize squareSizeFunc
+
+	mtx   sync.Mutex
+	sizes map[int64]uint64
+}
+
+func newSquareSizeCheck(expected int, squareSize squareSizeFunc) (*squareSizeCheck, error) {
+	if expected < 0 {
+		return nil, fmt.Errorf("expected square size must not be negative, got %d", expected)
+	}
+	return &squareSizeCheck{
+		expected:   uint64(expected),
+		squareSize: squareSize,
+		sizes:      make(map[int64]uint64),
+	}, nil
+}
+
+// grpcSquareSize queries the square size of a block from the node.
+func grpcSquareSize(client tmservice.ServiceClient) squareSizeFunc {
+	return func(ctx context.Context, height int64) (uint64, error) {
+		resp, err := client.GetBlockByHeight(ctx, &tmservice.GetBlockByHeightRequest{Height: height})
+		if err != nil {
+			return 0, err
+		}
+		return resp.SdkBlock.Data.SquareSize, nil
+	}
+}
+
+// check returns the square size of the block at the height in which a PFB was
+// committed, logging a warning if it doesn't match the expected size. Failed
+// queries are logged and return zero as the transaction itself was committed.
+func (c *squareSizeCheck) check(ctx context.Context, height int64) uint64 {
+	if c == nil {
+		return 0
+	}
+	size, err := c.lookup(ctx, height)
+	if err != nil {
+		log.Warn().Err(err).Int64("height", height).Msg("failed to query the square size")
+		return 0
+	}
+	if c.expected != 0 && size != c.expected {
+		log.Warn().
+			Int64("height", height).
+			Uint64("square size", size).
+			Uint64("expected", c.expected).
+			Msg("unexpected square size")
+	}
+	return size
+}
+
+func (c *squareSizeCheck) lookup(ctx context.Context, height int64) (uint64, error) {
+	c.mtx.Lock()
+	size, ok := c.sizes[height]
+	c.mtx.Unlock()
+	if ok {
+		return size, nil
+	}
+
+	size, err := c.squareSize(ctx, height)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.sizes[height] = size
+	for cached := range c.sizes {
+		if cached < height-squareSizeCacheHeights {
+			delete(c.sizes, cached)
+		}
+	}
+	return size, nil
+}
+
+// setSquareSizeCheck records the square size of the blocks that include PFBs
+// and warns if it differs from expected. An expected size of zero only records
+// the sizes.
+func (am *AccountManager) setSquareSizeCheck(expected int) error {
+	check, err := newSquareSizeCheck(expected, grpcSquareSize(tmservice.NewServiceClient(am.conn)))
+	if err != nil {
+		return err
+	}
+	am.mtx.Lock()
+	defer am.mtx.Unlock()
+	am.squareSizes = check
+	return nil
+}
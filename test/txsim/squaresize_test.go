@@ -0,0 +1,43 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSquareSizeCheck(t *testing.T) {
+	_, err := newSquareSizeCheck(-1, nil)
+	require.Error(t, err)
+
+	var queries []int64
+	squareSize := func(_ context.Context, height int64) (uint64, error) {
+		queries = append(queries, height)
+		if height == 3 {
+			return 0, errors.New("block not found")
+		}
+		return uint64(height * 8), nil
+	}
+	check, err := newSquareSizeCheck(8, squareSize)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 8, check.check(context.Background(), 1))
+	// the size of each block is only queried once
+	require.EqualValues(t, 8, check.check(context.Background(), 1))
+	// a different size is returned along with a warning
+	require.EqualValues(t, 16, check.check(context.Background(), 2))
+	// failed queries return zero
+	require.Zero(t, check.check(context.Background(), 3))
+	require.Equal(t, []int64{1, 2, 3}, queries)
+
+	// old heights are evicted from the cache
+	check.check(context.Background(), 100)
+	require.NotContains(t, check.sizes, int64(1))
+	require.Contains(t, check.sizes, int64(100))
+
+	// a nil check does nothing
+	var disabled *squareSizeCheck
+	require.Zero(t, disabled.check(context.Background(), 1))
+}
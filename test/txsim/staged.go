@@ -0,0 +1,118 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/gogo/protobuf/grpc"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/time/rate"
+)
+
+var _ Sequence = &stagedSequence{}
+
+// Stage is a phase of a StagedSequence during which the operations are drawn
+// from its sequence.
+type Stage struct {
+	Sequence Sequence
+	// Duration is the time for which the stage is active. A stage with a
+	// non-positive duration is active until its sequence ends.
+	Duration time.Duration
+	// Rate throttles the stage to at most Rate operations per second. A rate of
+	// zero means no throttling.
+	Rate int
+}
+
+// stagedSequence runs a set of stages one after the other.
+type stagedSequence struct {
+	stages []Stage
+
+	index      int
+	stageStart time.Time
+	limiter    *rate.Limiter
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+}
+
+// StagedSequence combines several sequences into a single sequence whose load
+// varies over time, i.e. a warm-up stage of light traffic followed by a burst
+// and a cool-down. The stages are run in order, starting from the first call to
+// Next. Each operation is drawn from the active stage, which transitions to the
+// next one once its duration has elapsed or its sequence has ended. The
+// combined sequence ends once the last stage has. The accounts of all stages
+// are allocated up front.
+func StagedSequence(stages ...Stage) Sequence {
+	return &stagedSequence{stages: stages, now: time.Now}
+}
+
+// Clone replicates each of the stages with the same duration and rate.
+func (s *stagedSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		stages := make([]Stage, len(s.stages))
+		for j, stage := range s.stages {
+			stages[j] = Stage{Sequence: stage.Sequence.Clone(1)[0], Duration: stage.Duration, Rate: stage.Rate}
+		}
+		sequenceGroup[i] = StagedSequence(stages...)
+	}
+	return sequenceGroup
+}
+
+// Init initializes the sequence of each stage.
+func (s *stagedSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, rand *rand.Rand, useFeegrant bool) {
+	for _, stage := range s.stages {
+		stage.Sequence.Init(ctx, querier, allocateAccounts, rand, useFeegrant)
+	}
+}
+
+// Next returns the next operation of the active stage, transitioning to the
+// following stages as needed. Any error other than ErrEndOfSequence is returned
+// immediately.
+func (s *stagedSequence) Next(ctx context.Context, querier grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	if s.stageStart.IsZero() && len(s.stages) > 0 {
+		s.start(0, "")
+	}
+	for s.index < len(s.stages) {
+		stage := s.stages[s.index]
+		if stage.Duration > 0 && s.now().Sub(s.stageStart) >= stage.Duration {
+			s.start(s.index+1, "duration elapsed")
+			continue
+		}
+		if err := waitForRate(ctx, s.limiter); err != nil {
+			return Operation{}, err
+		}
+		op, err := stage.Sequence.Next(ctx, querier, rand)
+		if errors.Is(err, ErrEndOfSequence) {
+			s.start(s.index+1, "sequence ended")
+			continue
+		}
+		return op, err
+	}
+	return Operation{}, ErrEndOfSequence
+}
+
+// start activates the stage at the index, which ends the sequence if it is past
+// the last stage.
+func (s *stagedSequence) start(index int, reason string) {
+	now := s.now()
+	if index > 0 {
+		log.Info().
+			Int("stage", s.index).
+			Dur("elapsed", now.Sub(s.stageStart)).
+			Str("reason", reason).
+			Msg("stage ended")
+	}
+	s.index = index
+	s.stageStart = now
+	if index >= len(s.stages) {
+		return
+	}
+	s.limiter = newLimiter(s.stages[index].Rate)
+	log.Info().
+		Int("stage", index).
+		Dur("duration", s.stages[index].Duration).
+		Int("rate", s.stages[index].Rate).
+		Msg("stage started")
+}
@@ -0,0 +1,88 @@
+package txsim
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStagedSequence(t *testing.T) {
+	t.Run("transitions once a sequence ends", func(t *testing.T) {
+		first, second := &countingSequence{length: 2}, &countingSequence{length: 3}
+		seq := StagedSequence(Stage{Sequence: first}, Stage{Sequence: second})
+		r := rand.New(rand.NewSource(1))
+		ops := 0
+		for {
+			_, err := seq.Next(context.Background(), nil, r)
+			if err != nil {
+				require.ErrorIs(t, err, ErrEndOfSequence)
+				break
+			}
+			ops++
+		}
+		require.Equal(t, 5, ops)
+		require.Equal(t, 2, first.calls)
+		require.Equal(t, 3, second.calls)
+	})
+
+	t.Run("transitions once the duration elapses", func(t *testing.T) {
+		warmUp, burst := &countingSequence{length: 1_000}, &countingSequence{length: 1_000}
+		seq := StagedSequence(
+			Stage{Sequence: warmUp, Duration: time.Minute},
+			Stage{Sequence: burst, Duration: time.Minute},
+		).(*stagedSequence)
+		now := time.Now()
+		seq.now = func() time.Time { return now }
+		r := rand.New(rand.NewSource(1))
+
+		next := func() {
+			_, err := seq.Next(context.Background(), nil, r)
+			require.NoError(t, err)
+		}
+		next()
+		now = now.Add(59 * time.Second)
+		next()
+		require.Equal(t, 2, warmUp.calls)
+
+		now = now.Add(time.Second)
+		next()
+		next()
+		require.Equal(t, 2, warmUp.calls)
+		require.Equal(t, 2, burst.calls)
+
+		now = now.Add(time.Minute)
+		_, err := seq.Next(context.Background(), nil, r)
+		require.ErrorIs(t, err, ErrEndOfSequence)
+		require.Equal(t, 2, burst.calls)
+	})
+
+	t.Run("throttles each stage to its rate", func(t *testing.T) {
+		seq := StagedSequence(Stage{Sequence: &countingSequence{length: 3}, Rate: 20})
+		r := rand.New(rand.NewSource(1))
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			_, err := seq.Next(context.Background(), nil, r)
+			require.NoError(t, err)
+		}
+		// the first operation isn't delayed
+		require.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+	})
+
+	t.Run("clones each stage", func(t *testing.T) {
+		seq := StagedSequence(Stage{Sequence: &countingSequence{length: 1}}, Stage{Sequence: &countingSequence{length: 1}})
+		clones := seq.Clone(2)
+		require.Len(t, clones, 2)
+		r := rand.New(rand.NewSource(1))
+		for _, clone := range clones {
+			for i := 0; i < 2; i++ {
+				_, err := clone.Next(context.Background(), nil, r)
+				require.NoError(t, err)
+			}
+			_, err := clone.Next(context.Background(), nil, r)
+			require.ErrorIs(t, err, ErrEndOfSequence)
+		}
+	})
+}
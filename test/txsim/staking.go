@@ -0,0 +1,229 @@
+package txsim
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/types"
+	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var _ Sequence = &StakingSequence{}
+
+// maxStakingEntries mirrors the default staking MaxEntries param: the maximum
+// number of concurrent unbonding delegations or redelegations between a pair.
+const maxStakingEntries = 7
+
+// StakingSequence sets up a sequence whereby a group of delegators randomly delegate,
+// redelegate and undelegate random amounts between a set of validators. Unlike the
+// StakeSequence, which has a single delegator that mostly claims rewards, this
+// sequence is aimed at generating load on the staking module itself. The sequence
+// ends when there are no validators or when the delegators have exhausted their funds.
+type StakingSequence struct {
+	numDelegators int
+	balance       int
+	validators    []string
+
+	delegators []types.AccAddress
+	// delegated and available track the delegator's stake per validator and
+	// the remaining balance that can be delegated
+	delegated map[string]map[string]int64
+	available map[string]int64
+	// entries tracks the number of unbonding or redelegation entries per
+	// delegator and validator(s)
+	entries map[string]int
+	// redelegatedTo tracks the validators per delegator that have received a
+	// redelegation which can't be redelegated from until it matures
+	redelegatedTo map[string]bool
+	index         int
+	initErr       error
+}
+
+// NewStakingSequence creates a sequence of numDelegators accounts each with
+// balance utia to stake.
+func NewStakingSequence(numDelegators, balance int) *StakingSequence {
+	return &StakingSequence{
+		numDelegators: numDelegators,
+		balance:       balance,
+	}
+}
+
+// WithValidators restricts the sequence to the provided validator operator
+// addresses. By default, all bonded validators are used.
+func (s *StakingSequence) WithValidators(validators ...string) *StakingSequence {
+	s.validators = validators
+	return s
+}
+
+func (s *StakingSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewStakingSequence(s.numDelegators, s.balance).WithValidators(s.validators...)
+	}
+	return sequenceGroup
+}
+
+// Init queries the current validator set, if not already provided, and
+// allocates an account for each delegator.
+func (s *StakingSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, _ *rand.Rand, useFeegrant bool) {
+	if len(s.validators) == 0 {
+		s.validators, s.initErr = getBondedValidators(ctx, querier)
+	}
+
+	funds := fundsForGas
+	if useFeegrant {
+		funds = 1
+	}
+	s.delegators = allocateAccounts(s.numDelegators, s.balance+funds)
+	s.delegated = make(map[string]map[string]int64, s.numDelegators)
+	s.available = make(map[string]int64, s.numDelegators)
+	s.entries = make(map[string]int)
+	s.redelegatedTo = make(map[string]bool)
+	for _, delegator := range s.delegators {
+		s.delegated[delegator.String()] = make(map[string]int64)
+		s.available[delegator.String()] = int64(s.balance)
+	}
+}
+
+// Next picks the next delegator in turn and randomly delegates, redelegates or
+// undelegates an amount drawn from the random source.
+func (s *StakingSequence) Next(_ context.Context, _ grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	if s.initErr != nil {
+		return Operation{}, fmt.Errorf("querying validators: %w", s.initErr)
+	}
+	if len(s.validators) == 0 {
+		return Operation{}, ErrEndOfSequence
+	}
+
+	// try each delegator in turn until one is able to make an operation
+	for i := 0; i < len(s.delegators); i++ {
+		delegator := s.delegators[s.index%len(s.delegators)]
+		s.index++
+
+		// try the actions in a random order
+		for _, action := range rand.Perm(3) {
+			var (
+				msg types.Msg
+				ok  bool
+			)
+			switch action {
+			case 0:
+				msg, ok = s.delegate(delegator, rand)
+			case 1:
+				msg, ok = s.redelegate(delegator, rand)
+			case 2:
+				msg, ok = s.undelegate(delegator, rand)
+			}
+			if ok {
+				return Operation{Msgs: []types.Msg{msg}}, nil
+			}
+		}
+	}
+
+	return Operation{}, ErrEndOfSequence
+}
+
+func (s *StakingSequence) delegate(delegator types.AccAddress, rand *rand.Rand) (types.Msg, bool) {
+	available := s.available[delegator.String()]
+	if available == 0 {
+		return nil, false
+	}
+	validator := s.validators[rand.Intn(len(s.validators))]
+	amount := rand.Int63n(available) + 1
+	s.available[delegator.String()] -= amount
+	s.delegated[delegator.String()][validator] += amount
+	return &staking.MsgDelegate{
+		DelegatorAddress: delegator.String(),
+		ValidatorAddress: validator,
+		Amount:           types.NewInt64Coin(appconsts.BondDenom, amount),
+	}, true
+}
+
+func (s *StakingSequence) redelegate(delegator types.AccAddress, rand *rand.Rand) (types.Msg, bool) {
+	if len(s.validators) < 2 {
+		return nil, false
+	}
+	src, amount, ok := s.randomDelegation(delegator, rand)
+	if !ok || s.redelegatedTo[entryKey(delegator, src)] {
+		return nil, false
+	}
+	dst := s.validators[rand.Intn(len(s.validators))]
+	key := entryKey(delegator, src, dst)
+	if dst == src || s.entries[key] >= maxStakingEntries {
+		return nil, false
+	}
+	amount = rand.Int63n(amount) + 1
+	s.entries[key]++
+	s.redelegatedTo[entryKey(delegator, dst)] = true
+	s.delegated[delegator.String()][src] -= amount
+	s.delegated[delegator.String()][dst] += amount
+	return &staking.MsgBeginRedelegate{
+		DelegatorAddress:    delegator.String(),
+		ValidatorSrcAddress: src,
+		ValidatorDstAddress: dst,
+		Amount:              types.NewInt64Coin(appconsts.BondDenom, amount),
+	}, true
+}
+
+func (s *StakingSequence) undelegate(delegator types.AccAddress, rand *rand.Rand) (types.Msg, bool) {
+	validator, amount, ok := s.randomDelegation(delegator, rand)
+	if !ok {
+		return nil, false
+	}
+	key := entryKey(delegator, validator)
+	if s.entries[key] >= maxStakingEntries {
+		return nil, false
+	}
+	// NOTE: undelegated funds are locked for the unbonding period and are
+	// therefore not returned to the available balance
+	amount = rand.Int63n(amount) + 1
+	s.entries[key]++
+	s.delegated[delegator.String()][validator] -= amount
+	return &staking.MsgUndelegate{
+		DelegatorAddress: delegator.String(),
+		ValidatorAddress: validator,
+		Amount:           types.NewInt64Coin(appconsts.BondDenom, amount),
+	}, true
+}
+
+// randomDelegation returns a random validator that the delegator has
+// delegated to along with the delegated amount.
+func (s *StakingSequence) randomDelegation(delegator types.AccAddress, rand *rand.Rand) (string, int64, bool) {
+	// iterate over the validators rather than the map for determinism
+	validators := make([]string, 0, len(s.validators))
+	for _, validator := range s.validators {
+		if s.delegated[delegator.String()][validator] > 0 {
+			validators = append(validators, validator)
+		}
+	}
+	if len(validators) == 0 {
+		return "", 0, false
+	}
+	validator := validators[rand.Intn(len(validators))]
+	return validator, s.delegated[delegator.String()][validator], true
+}
+
+func entryKey(delegator types.AccAddress, validators ...string) string {
+	key := delegator.String()
+	for _, validator := range validators {
+		key += "/" + validator
+	}
+	return key
+}
+
+func getBondedValidators(ctx context.Context, conn grpc.ClientConn) ([]string, error) {
+	resp, err := staking.NewQueryClient(conn).Validators(ctx, &staking.QueryValidatorsRequest{
+		Status: staking.BondStatusBonded,
+	})
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]string, len(resp.Validators))
+	for i, val := range resp.Validators {
+		validators[i] = val.OperatorAddress
+	}
+	return validators, nil
+}
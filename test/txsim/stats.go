@@ -0,0 +1,238 @@
+package txsim
+
+import (
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RunResult summarises the transactions submitted during a txsim run both
+// per sequence and in aggregate.
+type RunResult struct {
+	Submitted int
+	Committed int
+	Errored   int
+	Rejected  int
+	Latency   LatencySummary
+	// Drained and Abandoned count the operations that were in flight when
+	// the run was cancelled and that were or weren't committed within the
+	// drain timeout.
+	Drained   int
+	Abandoned int
+	// LatencyBreaches counts the committed transactions whose latency
+	// exceeded the threshold set by WithLatencyThreshold.
+	LatencyBreaches int
+	// Sequences contains the results of each sequence, indexed in the order
+	// that the sequences were passed to Run.
+	Sequences []SequenceResult
+	// SquareSizes counts the committed PFBs of all sequences by the square
+	// size of the block that included them.
+	SquareSizes map[uint64]int
+}
+
+// SequenceResult summarises the transactions submitted by a single sequence.
+type SequenceResult struct {
+	Submitted int
+	Committed int
+	Errored   int
+	Rejected  int
+	Latency   LatencySummary
+	// Drained and Abandoned count the operations that were in flight when
+	// the run was cancelled and that were or weren't committed.
+	Drained   int
+	Abandoned int
+	// LatencyBreaches counts the committed transactions whose latency
+	// exceeded the threshold set by WithLatencyThreshold.
+	LatencyBreaches int
+	// Rejections counts the intentionally invalid transactions that were
+	// rejected by their error code. They are included in Submitted and
+	// Rejected but not in Errored.
+	Rejections map[RejectionCode]int
+	// TxHashes are the hashes of the committed transactions in the order they
+	// were committed. They are only recorded if WithTxHashes is set.
+	TxHashes []string
+	// SquareSizes counts the committed PFBs by the square size of the block
+	// that included them. They are only recorded if WithSquareSizeCheck is
+	// set.
+	SquareSizes map[uint64]int
+}
+
+// RejectionCode identifies the error with which a transaction was rejected.
+type RejectionCode struct {
+	Codespace string
+	Code      uint32
+}
+
+// LatencySummary describes the distribution of the time taken between
+// submitting a transaction and it being committed.
+type LatencySummary struct {
+	Min  time.Duration
+	Max  time.Duration
+	Mean time.Duration
+	P99  time.Duration
+}
+
+// sequenceStats records the outcome of each operation of a sequence. It is
+// owned by a single goroutine and is not concurrently safe.
+type sequenceStats struct {
+	submitted int
+	committed int
+	errored   int
+	rejected  int
+	drained   int
+	abandoned int
+	latencies []time.Duration
+	txHashes  []string
+	// rejections counts the rejected transactions by their error code
+	rejections map[RejectionCode]int
+	// squareSizes counts the committed PFBs by the square size of their block
+	squareSizes map[uint64]int
+	// latencyBreaches counts the commits that exceeded the latency threshold
+	latencyBreaches int
+	// lastNonce is the sequence number of the last committed transaction
+	lastNonce uint64
+	// lastErr is the error that terminated the sequence
+	lastErr error
+}
+
+func (s *sequenceStats) recordCommit(latency time.Duration) {
+	s.submitted++
+	s.committed++
+	s.latencies = append(s.latencies, latency)
+}
+
+func (s *sequenceStats) recordTxHash(hash string) {
+	s.txHashes = append(s.txHashes, hash)
+}
+
+func (s *sequenceStats) recordSquareSize(size uint64) {
+	if s.squareSizes == nil {
+		s.squareSizes = make(map[uint64]int)
+	}
+	s.squareSizes[size]++
+}
+
+func (s *sequenceStats) recordLatencyBreach() {
+	s.latencyBreaches++
+}
+
+// recordDrain records whether an operation in flight when the run was
+// cancelled was committed.
+func (s *sequenceStats) recordDrain(committed bool) {
+	if committed {
+		s.drained++
+	} else {
+		s.abandoned++
+	}
+}
+
+func (s *sequenceStats) recordError() {
+	s.submitted++
+	s.errored++
+}
+
+func (s *sequenceStats) recordRejection(code RejectionCode) {
+	s.submitted++
+	s.rejected++
+	if s.rejections == nil {
+		s.rejections = make(map[RejectionCode]int)
+	}
+	s.rejections[code]++
+}
+
+func (s *sequenceStats) result() SequenceResult {
+	return SequenceResult{
+		Submitted:       s.submitted,
+		Committed:       s.committed,
+		Errored:         s.errored,
+		Rejected:        s.rejected,
+		Drained:         s.drained,
+		Abandoned:       s.abandoned,
+		LatencyBreaches: s.latencyBreaches,
+		Latency:         summarizeLatencies(s.latencies),
+		Rejections:      s.rejections,
+		TxHashes:        s.txHashes,
+		SquareSizes:     s.squareSizes,
+	}
+}
+
+// logSummary logs what each sequence accomplished. It is called once all
+// sequences have terminated, regardless of the reason. NOTE: zerolog writes
+// synchronously so there is no buffered output to flush.
+func logSummary(stats []*sequenceStats) {
+	for seqID, s := range stats {
+		event := log.Info().
+			Int("sequence", seqID).
+			Int("operations", s.committed).
+			Int("errors", s.errored)
+		if s.rejected > 0 {
+			event = event.Int("rejections", s.rejected)
+		}
+		if s.drained > 0 || s.abandoned > 0 {
+			event = event.Int("drained", s.drained).Int("abandoned", s.abandoned)
+		}
+		if s.latencyBreaches > 0 {
+			event = event.Int("latency breaches", s.latencyBreaches)
+		}
+		if s.committed > 0 {
+			event = event.Uint64("last nonce", s.lastNonce)
+		}
+		if s.lastErr != nil {
+			event = event.AnErr("last error", s.lastErr)
+		}
+		event.Msg("sequence summary")
+	}
+}
+
+// newRunResult aggregates the stats of each sequence into a RunResult.
+func newRunResult(stats []*sequenceStats) *RunResult {
+	result := &RunResult{
+		Sequences: make([]SequenceResult, len(stats)),
+	}
+	latencies := make([]time.Duration, 0)
+	for i, s := range stats {
+		result.Sequences[i] = s.result()
+		result.Submitted += s.submitted
+		result.Committed += s.committed
+		result.Errored += s.errored
+		result.Rejected += s.rejected
+		result.Drained += s.drained
+		result.Abandoned += s.abandoned
+		result.LatencyBreaches += s.latencyBreaches
+		latencies = append(latencies, s.latencies...)
+		for size, count := range s.squareSizes {
+			if result.SquareSizes == nil {
+				result.SquareSizes = make(map[uint64]int)
+			}
+			result.SquareSizes[size] += count
+		}
+	}
+	result.Latency = summarizeLatencies(latencies)
+	return result
+}
+
+// summarizeLatencies computes the min, max, mean and 99th percentile of the
+// provided latencies. An empty set returns a zero summary.
+func summarizeLatencies(latencies []time.Duration) LatencySummary {
+	if len(latencies) == 0 {
+		return LatencySummary{}
+	}
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	// use the nearest-rank method for the percentile
+	p99Index := (len(sorted)*99+99)/100 - 1
+
+	return LatencySummary{
+		Min:  sorted[0],
+		Max:  sorted[len(sorted)-1],
+		Mean: total / time.Duration(len(sorted)),
+		P99:  sorted[p99Index],
+	}
+}
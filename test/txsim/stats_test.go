@@ -0,0 +1,92 @@
+package txsim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarizeLatencies(t *testing.T) {
+	require.Equal(t, LatencySummary{}, summarizeLatencies(nil))
+
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	summary := summarizeLatencies(latencies)
+	require.Equal(t, time.Millisecond, summary.Min)
+	require.Equal(t, 100*time.Millisecond, summary.Max)
+	require.Equal(t, 50500*time.Microsecond, summary.Mean)
+	require.Equal(t, 99*time.Millisecond, summary.P99)
+	// the input should not be reordered
+	require.Equal(t, 100*time.Millisecond, latencies[0])
+}
+
+func TestNewRunResult(t *testing.T) {
+	first := &sequenceStats{}
+	first.recordCommit(time.Second)
+	first.recordError()
+	second := &sequenceStats{}
+	second.recordCommit(3 * time.Second)
+
+	result := newRunResult([]*sequenceStats{first, second})
+	require.Equal(t, 3, result.Submitted)
+	require.Equal(t, 2, result.Committed)
+	require.Equal(t, 1, result.Errored)
+	require.Equal(t, 2*time.Second, result.Latency.Mean)
+	require.Len(t, result.Sequences, 2)
+	require.Equal(t, 1, result.Sequences[0].Errored)
+	require.Equal(t, 3*time.Second, result.Sequences[1].Latency.Max)
+}
+
+func TestNewRunResultTxHashes(t *testing.T) {
+	stats := &sequenceStats{}
+	stats.recordCommit(time.Second)
+	stats.recordTxHash("A1")
+	stats.recordCommit(time.Second)
+	stats.recordTxHash("B2")
+
+	result := newRunResult([]*sequenceStats{stats, {}})
+	require.Equal(t, []string{"A1", "B2"}, result.Sequences[0].TxHashes)
+	require.Empty(t, result.Sequences[1].TxHashes)
+}
+
+func TestNewRunResultSquareSizes(t *testing.T) {
+	first, second := &sequenceStats{}, &sequenceStats{}
+	first.recordSquareSize(8)
+	first.recordSquareSize(16)
+	second.recordSquareSize(16)
+
+	result := newRunResult([]*sequenceStats{first, second, {}})
+	require.Equal(t, map[uint64]int{8: 1, 16: 1}, result.Sequences[0].SquareSizes)
+	require.Empty(t, result.Sequences[2].SquareSizes)
+	require.Equal(t, map[uint64]int{8: 1, 16: 2}, result.SquareSizes)
+}
+
+func TestLatencyBreaches(t *testing.T) {
+	am := &AccountManager{}
+	require.Error(t, am.setLatencyThreshold(-time.Second))
+	res := &types.TxResponse{Height: 1, TxHash: "A1"}
+	// no threshold is set
+	require.False(t, am.checkLatency(time.Hour, res))
+	require.NoError(t, am.setLatencyThreshold(time.Second))
+	require.False(t, am.checkLatency(time.Second, res))
+	require.True(t, am.checkLatency(2*time.Second, res))
+
+	stats := &sequenceStats{}
+	for i := 0; i < 4; i++ {
+		stats.recordCommit(time.Second)
+	}
+	stats.recordLatencyBreach()
+	result := newRunResult([]*sequenceStats{stats})
+	require.Equal(t, 1, result.LatencyBreaches)
+	require.Equal(t, 1, result.Sequences[0].LatencyBreaches)
+
+	// one of four transactions breached the threshold
+	require.NoError(t, checkLatencyBreaches(result, 0))
+	require.NoError(t, checkLatencyBreaches(result, 0.25))
+	require.ErrorIs(t, checkLatencyBreaches(result, 0.2), ErrLatencyThreshold)
+	require.NoError(t, checkLatencyBreaches(&RunResult{}, 0.2))
+}
@@ -0,0 +1,52 @@
+package txsim
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// loadTLSConfig returns a TLS configuration that trusts the certificates in
+// the PEM encoded file.
+func loadTLSConfig(certFile string) (*tls.Config, error) {
+	pem, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading certificate file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in %s", certFile)
+	}
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+}
+
+// transportCredentials returns the credentials for dialing the endpoint. If no
+// TLS configuration is provided, insecure credentials are used. Otherwise the
+// server name is set to the endpoint's host or, if already set, validated
+// against it.
+func transportCredentials(config *tls.Config, address string) (credentials.TransportCredentials, error) {
+	if config == nil {
+		return insecure.NewCredentials(), nil
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		// the address does not contain a port
+		host = address
+	}
+
+	config = config.Clone()
+	switch config.ServerName {
+	case "":
+		config.ServerName = host
+	case host:
+	default:
+		return nil, fmt.Errorf("tls server name %s does not match endpoint host %s", config.ServerName, host)
+	}
+	return credentials.NewTLS(config), nil
+}
@@ -0,0 +1,109 @@
+// Package txsimtest provides in-process implementations of the txsim interfaces
+// so that the lifecycle of txsim.RunSequences can be tested without a node.
+package txsimtest
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+
+	"github.com/celestiaorg/celestia-app/v2/test/txsim"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var _ txsim.Sequence = &StubSequence{}
+
+// StubSequence is a sequence of n empty operations. Once they have been
+// generated, it returns txsim.ErrEndOfSequence or, if set, a synthetic error.
+type StubSequence struct {
+	n   int
+	err error
+
+	mtx   sync.Mutex
+	calls int
+}
+
+// NewStubSequence returns a sequence of n empty operations.
+func NewStubSequence(n int) *StubSequence {
+	return &StubSequence{n: n, err: txsim.ErrEndOfSequence}
+}
+
+// WithError returns err instead of txsim.ErrEndOfSequence after the n
+// operations have been generated.
+func (s *StubSequence) WithError(err error) *StubSequence {
+	s.err = err
+	return s
+}
+
+// Calls returns the number of times Next has been called.
+func (s *StubSequence) Calls() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.calls
+}
+
+func (s *StubSequence) Clone(n int) []txsim.Sequence {
+	sequenceGroup := make([]txsim.Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewStubSequence(s.n).WithError(s.err)
+	}
+	return sequenceGroup
+}
+
+func (s *StubSequence) Init(_ context.Context, _ grpc.ClientConn, _ txsim.AccountAllocator, _ *rand.Rand, _ bool) {
+}
+
+func (s *StubSequence) Next(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) (txsim.Operation, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.calls++
+	if s.calls > s.n {
+		return txsim.Operation{}, s.err
+	}
+	return txsim.Operation{}, nil
+}
+
+var _ txsim.Submitter = &StubSubmitter{}
+
+// StubSubmitter records the operations it is given instead of submitting them.
+// It can be set to fail every submission after a number of successful ones.
+type StubSubmitter struct {
+	mtx       sync.Mutex
+	submitted []txsim.Operation
+	failAfter int
+	err       error
+}
+
+// NewStubSubmitter returns a submitter that accepts every operation.
+func NewStubSubmitter() *StubSubmitter {
+	return &StubSubmitter{}
+}
+
+// WithError fails every submission with err once n operations have been
+// accepted.
+func (s *StubSubmitter) WithError(n int, err error) *StubSubmitter {
+	s.failAfter = n
+	s.err = err
+	return s
+}
+
+// Submit records the operation or returns the configured error.
+func (s *StubSubmitter) Submit(ctx context.Context, op txsim.Operation) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.err != nil && len(s.submitted) >= s.failAfter {
+		return s.err
+	}
+	s.submitted = append(s.submitted, op)
+	return nil
+}
+
+// Submitted returns the number of operations that were accepted.
+func (s *StubSubmitter) Submitted() int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return len(s.submitted)
+}
@@ -0,0 +1,69 @@
+package txsimtest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/test/txsim"
+	"github.com/celestiaorg/celestia-app/v2/test/txsim/txsimtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSequencesEnd(t *testing.T) {
+	submitter := txsimtest.NewStubSubmitter()
+	sequences := txsimtest.NewStubSequence(5).Clone(3)
+
+	result, err := txsim.RunSequences(context.Background(), submitter, txsim.DefaultOptions(), sequences...)
+	require.NoError(t, err)
+	require.Equal(t, 15, submitter.Submitted())
+	require.Equal(t, 15, result.Committed)
+	for _, sequence := range result.Sequences {
+		require.Equal(t, 5, sequence.Committed)
+	}
+}
+
+func TestRunSequencesReturnsSequenceError(t *testing.T) {
+	errSynthetic := errors.New("synthetic error")
+	sequences := []txsim.Sequence{
+		txsimtest.NewStubSequence(5),
+		txsimtest.NewStubSequence(2).WithError(errSynthetic),
+	}
+
+	result, err := txsim.RunSequences(context.Background(), txsimtest.NewStubSubmitter(), txsim.DefaultOptions(), sequences...)
+	require.ErrorIs(t, err, errSynthetic)
+	require.ErrorContains(t, err, "sequence 1")
+	// the failure of one sequence doesn't stop the others
+	require.Equal(t, 5, result.Sequences[0].Committed)
+	require.Equal(t, 2, result.Sequences[1].Committed)
+}
+
+func TestRunSequencesReturnsSubmitError(t *testing.T) {
+	errSubmit := errors.New("submit failed")
+	submitter := txsimtest.NewStubSubmitter().WithError(3, errSubmit)
+
+	result, err := txsim.RunSequences(context.Background(), submitter, txsim.DefaultOptions(), txsimtest.NewStubSequence(10))
+	require.ErrorIs(t, err, errSubmit)
+	require.Equal(t, 3, result.Committed)
+	require.Equal(t, 1, result.Errored)
+}
+
+func TestRunSequencesCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sequence := txsimtest.NewStubSequence(10)
+	_, err := txsim.RunSequences(ctx, txsimtest.NewStubSubmitter(), txsim.DefaultOptions(), sequence)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRunSequencesNoOperations(t *testing.T) {
+	submitter := txsimtest.NewStubSubmitter()
+	sequences := txsimtest.NewStubSequence(0).Clone(2)
+
+	result, err := txsim.RunSequences(context.Background(), submitter, txsim.DefaultOptions(), sequences...)
+	require.ErrorIs(t, err, txsim.ErrNoOperations)
+	require.Equal(t, 0, submitter.Submitted())
+	require.Equal(t, 0, result.Submitted)
+	require.Len(t, result.Sequences, 2)
+}
@@ -0,0 +1,127 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	"github.com/cosmos/cosmos-sdk/client/grpc/reflection"
+	"github.com/cosmos/cosmos-sdk/types"
+	vesting "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/gogo/protobuf/grpc"
+)
+
+var _ Sequence = &VestingSequence{}
+
+// ErrVestingNotSupported is returned by the VestingSequence if the node doesn't
+// register MsgCreateVestingAccount.
+var ErrVestingNotSupported = errors.New("vesting accounts are not supported by the node")
+
+// VestingSequence creates numAccounts vesting accounts, one per transaction,
+// from a single funder. Each account is randomly either a continuous or a
+// delayed vesting account and vests between 1 and maxAmount utia over a random
+// period of up to maxPeriod. The destination addresses are drawn from the seeded
+// random source as vesting accounts can only be created for addresses that
+// don't exist yet. The sequence ends once all accounts have been created.
+//
+// NOTE: the account manager only holds the keys of the accounts it funds so
+// spends from the vesting accounts are not attempted.
+type VestingSequence struct {
+	numAccounts int
+	maxAmount   int
+	maxPeriod   time.Duration
+
+	funder       types.AccAddress
+	destinations []types.AccAddress
+	index        int
+	initErr      error
+}
+
+// NewVestingSequence creates a sequence that creates numAccounts vesting
+// accounts each vesting between 1 and maxAmount utia over up to maxPeriod.
+func NewVestingSequence(numAccounts, maxAmount int, maxPeriod time.Duration) *VestingSequence {
+	return &VestingSequence{
+		numAccounts: numAccounts,
+		maxAmount:   maxAmount,
+		maxPeriod:   maxPeriod,
+	}
+}
+
+func (s *VestingSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = NewVestingSequence(s.numAccounts, s.maxAmount, s.maxPeriod)
+	}
+	return sequenceGroup
+}
+
+// Init checks that the node supports vesting accounts, allocates the funder,
+// with enough funds to cover the maximum amount and the fee of each account,
+// and draws the destination addresses.
+func (s *VestingSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, rand *rand.Rand, useFeegrant bool) {
+	if s.numAccounts < 1 || s.maxAmount < 1 || s.maxPeriod < time.Second {
+		s.initErr = fmt.Errorf("vesting requires positive accounts and amount and a period of at least 1s, got %d, %d and %v", s.numAccounts, s.maxAmount, s.maxPeriod)
+		return
+	}
+	if err := checkVestingSupported(ctx, querier); err != nil {
+		s.initErr = err
+		return
+	}
+
+	fee := int(defaultFee)
+	if useFeegrant {
+		fee = 0
+	}
+	s.funder = allocateAccounts(1, s.numAccounts*(s.maxAmount+fee))[0]
+
+	s.destinations = make([]types.AccAddress, s.numAccounts)
+	for i := range s.destinations {
+		address := make([]byte, 20)
+		_, _ = rand.Read(address)
+		s.destinations[i] = address
+	}
+}
+
+// Next creates the next vesting account with a random amount, end time and
+// vesting schedule.
+func (s *VestingSequence) Next(_ context.Context, _ grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	if s.initErr != nil {
+		return Operation{}, s.initErr
+	}
+	if s.index >= len(s.destinations) {
+		return Operation{}, ErrEndOfSequence
+	}
+
+	amount := types.NewCoins(types.NewInt64Coin(appconsts.BondDenom, int64(1+rand.Intn(s.maxAmount))))
+	period := time.Second + time.Duration(rand.Int63n(int64(s.maxPeriod-time.Second)+1))
+	endTime := time.Now().Add(period).Unix()
+	delayed := rand.Intn(2) == 0
+
+	msg := vesting.NewMsgCreateVestingAccount(s.funder, s.destinations[s.index], amount, endTime, delayed)
+	s.index++
+	return Operation{
+		Msgs:     []types.Msg{msg},
+		GasLimit: DefaultGasLimit,
+	}, nil
+}
+
+// checkVestingSupported returns ErrVestingNotSupported if MsgCreateVestingAccount
+// is not registered by the node.
+func checkVestingSupported(ctx context.Context, querier grpc.ClientConn) error {
+	resp, err := reflection.NewReflectionServiceClient(querier).ListImplementations(ctx, &reflection.ListImplementationsRequest{
+		InterfaceName: "cosmos.base.v1beta1.Msg",
+	})
+	if err != nil {
+		return fmt.Errorf("querying registered messages: %w", err)
+	}
+	msgTypeURL := types.MsgTypeURL(&vesting.MsgCreateVestingAccount{})
+	for _, name := range resp.ImplementationMessageNames {
+		if name == msgTypeURL {
+			return nil
+		}
+	}
+	return ErrVestingNotSupported
+}
@@ -0,0 +1,120 @@
+package txsim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/gogo/protobuf/grpc"
+)
+
+var _ Sequence = &weightedSequence{}
+
+type weightedEntry struct {
+	sequence Sequence
+	weight   float64
+	ended    bool
+}
+
+// weightedSequence multiplexes a set of sequences. Each operation is drawn
+// from one of the sequences, chosen at random according to their weights.
+type weightedSequence struct {
+	entries []*weightedEntry
+}
+
+// WeightedSequence combines several sequences into a single sequence so that
+// the mix of transactions can be controlled. For each operation, one of the
+// sequences is picked with a probability proportional to its weight, i.e.
+// weights of 0.7, 0.2 and 0.1 produce roughly 70%, 20% and 10% of the
+// operations from each sequence. Sequences with a non-positive weight are never
+// picked. Once a sequence ends, the remaining sequences are picked according to
+// their relative weights and the combined sequence ends once all have ended.
+//
+// As map iteration is random, the sequences are ordered by type and weight. For
+// deterministic runs, sequences of the same type and weight should be
+// configured identically.
+func WeightedSequence(weights map[Sequence]float64) Sequence {
+	entries := make([]*weightedEntry, 0, len(weights))
+	for sequence, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		entries = append(entries, &weightedEntry{sequence: sequence, weight: weight})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		ti, tj := fmt.Sprintf("%T", entries[i].sequence), fmt.Sprintf("%T", entries[j].sequence)
+		if ti != tj {
+			return ti < tj
+		}
+		return entries[i].weight < entries[j].weight
+	})
+	return &weightedSequence{entries: entries}
+}
+
+// Clone replicates each of the underlying sequences with the same weights.
+func (s *weightedSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		entries := make([]*weightedEntry, len(s.entries))
+		for j, entry := range s.entries {
+			entries[j] = &weightedEntry{sequence: entry.sequence.Clone(1)[0], weight: entry.weight}
+		}
+		sequenceGroup[i] = &weightedSequence{entries: entries}
+	}
+	return sequenceGroup
+}
+
+// Init initializes each of the underlying sequences.
+func (s *weightedSequence) Init(ctx context.Context, querier grpc.ClientConn, allocateAccounts AccountAllocator, rand *rand.Rand, useFeegrant bool) {
+	for _, entry := range s.entries {
+		entry.sequence.Init(ctx, querier, allocateAccounts, rand, useFeegrant)
+	}
+}
+
+// Next returns the next operation of a randomly picked sequence. Any error
+// other than ErrEndOfSequence is returned immediately.
+func (s *weightedSequence) Next(ctx context.Context, querier grpc.ClientConn, rand *rand.Rand) (Operation, error) {
+	for {
+		entry := s.pick(rand)
+		if entry == nil {
+			return Operation{}, ErrEndOfSequence
+		}
+		op, err := entry.sequence.Next(ctx, querier, rand)
+		if errors.Is(err, ErrEndOfSequence) {
+			entry.ended = true
+			continue
+		}
+		return op, err
+	}
+}
+
+// pick returns a random sequence that hasn't ended according to the weights.
+// It returns nil if all sequences have ended.
+func (s *weightedSequence) pick(rand *rand.Rand) *weightedEntry {
+	var total float64
+	for _, entry := range s.entries {
+		if !entry.ended {
+			total += entry.weight
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+
+	n := rand.Float64() * total
+	var last *weightedEntry
+	for _, entry := range s.entries {
+		if entry.ended {
+			continue
+		}
+		if n < entry.weight {
+			return entry
+		}
+		n -= entry.weight
+		last = entry
+	}
+	// guard against floating point error
+	return last
+}
@@ -0,0 +1,81 @@
+package txsim
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/gogo/protobuf/grpc"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSequence returns empty operations until it has been called length
+// times.
+type countingSequence struct {
+	length int
+	calls  int
+}
+
+func (s *countingSequence) Clone(n int) []Sequence {
+	sequenceGroup := make([]Sequence, n)
+	for i := 0; i < n; i++ {
+		sequenceGroup[i] = &countingSequence{length: s.length}
+	}
+	return sequenceGroup
+}
+
+func (s *countingSequence) Init(_ context.Context, _ grpc.ClientConn, _ AccountAllocator, _ *rand.Rand, _ bool) {
+}
+
+func (s *countingSequence) Next(_ context.Context, _ grpc.ClientConn, _ *rand.Rand) (Operation, error) {
+	if s.calls >= s.length {
+		return Operation{}, ErrEndOfSequence
+	}
+	s.calls++
+	return Operation{}, nil
+}
+
+func TestWeightedSequence(t *testing.T) {
+	t.Run("respects weights", func(t *testing.T) {
+		heavy, light := &countingSequence{length: 10_000}, &countingSequence{length: 10_000}
+		seq := WeightedSequence(map[Sequence]float64{heavy: 0.8, light: 0.2})
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			_, err := seq.Next(context.Background(), nil, r)
+			require.NoError(t, err)
+		}
+		require.InDelta(t, 800, heavy.calls, 50)
+		require.InDelta(t, 200, light.calls, 50)
+	})
+
+	t.Run("ends once all sequences have ended", func(t *testing.T) {
+		first, second := &countingSequence{length: 3}, &countingSequence{length: 5}
+		ignored := &countingSequence{length: 5}
+		seq := WeightedSequence(map[Sequence]float64{first: 1, second: 1, ignored: 0})
+		r := rand.New(rand.NewSource(1))
+		ops := 0
+		for {
+			_, err := seq.Next(context.Background(), nil, r)
+			if err != nil {
+				require.ErrorIs(t, err, ErrEndOfSequence)
+				break
+			}
+			ops++
+		}
+		require.Equal(t, 8, ops)
+		require.Zero(t, ignored.calls)
+	})
+
+	t.Run("clones each sequence", func(t *testing.T) {
+		seq := WeightedSequence(map[Sequence]float64{&countingSequence{length: 1}: 1})
+		clones := seq.Clone(2)
+		require.Len(t, clones, 2)
+		r := rand.New(rand.NewSource(1))
+		for _, clone := range clones {
+			_, err := clone.Next(context.Background(), nil, r)
+			require.NoError(t, err)
+			_, err = clone.Next(context.Background(), nil, r)
+			require.ErrorIs(t, err, ErrEndOfSequence)
+		}
+	})
+}
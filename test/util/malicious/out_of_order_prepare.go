@@ -39,6 +39,10 @@ func (a *App) OutOfOrderPrepareProposal(req abci.RequestPrepareProposal) abci.Re
 		ante.DefaultSigVerificationGasConsumer,
 		a.IBCKeeper,
 		a.ParamsKeeper,
+		// the maximum gas price and the fee grant priority are node specific
+		// and only apply to CheckTx
+		sdk.DecCoin{},
+		ante.FeeGrantPriorityFee,
 		a.MsgGateKeeper,
 	)
 
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/celestiaorg/celestia-app/v2/x/minfee"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/store"
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramskeeper "github.com/cosmos/cosmos-sdk/x/params/keeper"
+	paramstypes "github.com/cosmos/cosmos-sdk/x/params/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/stretchr/testify/require"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
+	dbm "github.com/tendermint/tm-db"
+)
+
+// SetupMinFeeParams returns a params keeper over an in-memory store with the
+// minfee and staking subspaces registered as in the app, and a context at the
+// provided app version in which the fields of params are stored. Nil and zero
+// fields are not stored so that tests can cover the defaults of unset params.
+func SetupMinFeeParams(t testing.TB, appVersion uint64, isCheckTx bool, params minfee.Params) (paramskeeper.Keeper, sdk.Context) {
+	storeKey := sdk.NewKVStoreKey(paramstypes.StoreKey)
+	tStoreKey := storetypes.NewTransientStoreKey(paramstypes.TStoreKey)
+
+	db := dbm.NewMemDB()
+	stateStore := store.NewCommitMultiStore(db)
+	stateStore.MountStoreWithDB(storeKey, storetypes.StoreTypeIAVL, db)
+	stateStore.MountStoreWithDB(tStoreKey, storetypes.StoreTypeTransient, nil)
+	require.NoError(t, stateStore.LoadLatestVersion())
+
+	registry := codectypes.NewInterfaceRegistry()
+	paramsKeeper := paramskeeper.NewKeeper(codec.NewProtoCodec(registry), codec.NewLegacyAmino(), storeKey, tStoreKey)
+	subspace := paramsKeeper.Subspace(minfee.ModuleName).WithKeyTable(minfee.ParamKeyTable())
+	paramsKeeper.Subspace(stakingtypes.ModuleName)
+
+	ctx := sdk.NewContext(stateStore, tmproto.Header{
+		Version: tmversion.Consensus{
+			App: appVersion,
+		},
+	}, isCheckTx, nil)
+
+	if !params.GlobalMinGasPrice.IsNil() {
+		subspace.Set(ctx, minfee.KeyGlobalMinGasPrice, params.GlobalMinGasPrice)
+	}
+	if params.PriorityScalingFactor != 0 {
+		subspace.Set(ctx, minfee.KeyPriorityScalingFactor, params.PriorityScalingFactor)
+	}
+	if params.NamespaceMinGasPrices != nil {
+		subspace.Set(ctx, minfee.KeyNamespaceMinGasPrices, params.NamespaceMinGasPrices)
+	}
+	if !params.MinFeePerBlobByte.IsNil() {
+		subspace.Set(ctx, minfee.KeyMinFeePerBlobByte, params.MinFeePerBlobByte)
+	}
+	return paramsKeeper, ctx
+}
@@ -0,0 +1,15 @@
+package minfee
+
+import (
+	"cosmossdk.io/errors"
+)
+
+var (
+	ErrGasPriceTooHigh = errors.Register(ModuleName, 2, "gas price exceeds the maximum for this node")
+	// ErrInsufficientNodeMinGasPrice is returned if the gas price is below the
+	// node's own minimum. Another node may still accept the transaction. Like
+	// ErrGasPriceTooHigh, it is only returned by CheckTx so it doesn't affect
+	// consensus. A fee below the network's global minimum is rejected with the
+	// sdk's ErrInsufficientFee as its code is part of the block results.
+	ErrInsufficientNodeMinGasPrice = errors.Register(ModuleName, 3, "insufficient minimum gas price for this node")
+)
@@ -0,0 +1,92 @@
+package minfee
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// RequiredFee returns the minimum fee for the provided gas limit given a
+// minimum gas price, where fee = minGasPrice * gas. A fractional fee is
+// always rounded up to the next whole unit.
+func RequiredFee(minGasPrice sdk.Dec, gas uint64) sdk.Int {
+	return minGasPrice.MulInt(sdk.NewIntFromUint64(gas)).Ceil().TruncateInt()
+}
+
+// RequiredBlobFee returns the minimum fee for a transaction that pays for
+// blobBytes bytes of blob data given a minimum fee per blob byte. Like
+// RequiredFee, a fractional fee is rounded up to the next whole unit.
+func RequiredBlobFee(minFeePerBlobByte sdk.Dec, blobBytes uint64) sdk.Int {
+	return minFeePerBlobByte.MulInt(sdk.NewIntFromUint64(blobBytes)).Ceil().TruncateInt()
+}
+
+// RequiredMinFee returns the minimum fee the network requires of a transaction
+// with the provided gas limit that pays for blobBytes bytes of blob data. It is
+// the larger of the fee required by the minimum gas price and by the minimum
+// fee per blob byte as the cost of a transaction is dominated by its blob data
+// rather than its gas. A nil or non-positive minimum doesn't apply.
+func RequiredMinFee(minGasPrice, minFeePerBlobByte sdk.Dec, gas, blobBytes uint64) sdk.Int {
+	minFee := sdk.ZeroInt()
+	if isPositive(minGasPrice) {
+		minFee = RequiredFee(minGasPrice, gas)
+	}
+	if isPositive(minFeePerBlobByte) {
+		if minBlobFee := RequiredBlobFee(minFeePerBlobByte, blobBytes); minBlobFee.GT(minFee) {
+			minFee = minBlobFee
+		}
+	}
+	return minFee
+}
+
+// GlobalMinimums returns the minimum gas price and the minimum fee per blob
+// byte that the network requires of a transaction whose PayForBlobs target
+// the provided namespaces. They only apply to app versions greater than one.
+// The minimum gas price is the highest override of the namespaces or else the
+// global minimum gas price. The default global minimum gas price is used if
+// the param is not set, i.e. at the upgrade height before it has been
+// initialized, and the minimum fee per blob byte is zero if it is not set.
+func GlobalMinimums(ctx sdk.Context, subspace paramtypes.Subspace, namespaces [][]byte) (minGasPrice, minFeePerBlobByte sdk.Dec) {
+	subspace.GetIfExists(ctx, KeyGlobalMinGasPrice, &minGasPrice)
+	minFeePerBlobByte = sdk.ZeroDec()
+	subspace.GetIfExists(ctx, KeyMinFeePerBlobByte, &minFeePerBlobByte)
+	if minGasPrice.IsNil() {
+		minGasPrice = DefaultGlobalMinGasPrice
+	}
+
+	if len(namespaces) > 0 {
+		var overrides []NamespaceMinGasPrice
+		subspace.GetIfExists(ctx, KeyNamespaceMinGasPrices, &overrides)
+		if namespaceMinGasPrice, ok := NamespaceMinGasPriceFor(overrides, namespaces); ok {
+			minGasPrice = namespaceMinGasPrice
+		}
+	}
+	return minGasPrice, minFeePerBlobByte
+}
+
+// isPositive returns true if the value is set and greater than zero.
+func isPositive(value sdk.Dec) bool {
+	return !value.IsNil() && value.IsPositive()
+}
+
+// NamespaceMinGasPriceFor returns the highest minimum gas price among the
+// overrides of the provided namespaces. It returns false if none of the
+// namespaces is overridden, in which case the global minimum gas price applies.
+func NamespaceMinGasPriceFor(overrides []NamespaceMinGasPrice, namespaces [][]byte) (sdk.Dec, bool) {
+	var (
+		minGasPrice sdk.Dec
+		found       bool
+	)
+	for _, override := range overrides {
+		for _, namespace := range namespaces {
+			if !bytes.Equal(override.Namespace, namespace) {
+				continue
+			}
+			if !found || override.MinGasPrice.GT(minGasPrice) {
+				minGasPrice = override.MinGasPrice
+				found = true
+			}
+		}
+	}
+	return minGasPrice, found
+}
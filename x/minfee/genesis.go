@@ -10,7 +10,9 @@ import (
 // DefaultGenesis returns the default genesis state.
 func DefaultGenesis() *GenesisState {
 	return &GenesisState{
-		GlobalMinGasPrice: DefaultGlobalMinGasPrice,
+		GlobalMinGasPrice:     DefaultGlobalMinGasPrice,
+		PriorityScalingFactor: DefaultPriorityScalingFactor,
+		MinFeePerBlobByte:     sdk.ZeroDec(),
 	}
 }
 
@@ -20,6 +22,24 @@ func ValidateGenesis(genesis *GenesisState) error {
 		return fmt.Errorf("global min gas price cannot be negative: %g", genesis.GlobalMinGasPrice)
 	}
 
+	// a zero priority scaling factor is replaced by the default
+	if genesis.PriorityScalingFactor != 0 {
+		if err := ValidatePriorityScalingFactor(genesis.PriorityScalingFactor); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateNamespaceMinGasPrices(genesis.NamespaceMinGasPrices); err != nil {
+		return err
+	}
+
+	// an unset min fee per blob byte disables the blob size based minimum
+	if !genesis.MinFeePerBlobByte.IsNil() {
+		if err := ValidateMinFeePerBlobByte(genesis.MinFeePerBlobByte); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -33,5 +53,27 @@ func ExportGenesis(ctx sdk.Context, k params.Keeper) *GenesisState {
 		panic("minfee subspace not set")
 	}
 
-	return &GenesisState{GlobalMinGasPrice: minGasPrice}
+	// the priority scaling factor is not set on networks that upgraded before
+	// it was introduced
+	priorityScalingFactor := uint64(DefaultPriorityScalingFactor)
+	if globalMinGasPrice.Has(ctx, KeyPriorityScalingFactor) {
+		globalMinGasPrice.Get(ctx, KeyPriorityScalingFactor, &priorityScalingFactor)
+	}
+
+	// the namespace overrides are not set on networks that upgraded before
+	// they were introduced
+	var namespaceMinGasPrices []NamespaceMinGasPrice
+	globalMinGasPrice.GetIfExists(ctx, KeyNamespaceMinGasPrices, &namespaceMinGasPrices)
+
+	// the min fee per blob byte is not set on networks that upgraded before
+	// it was introduced, which disables it
+	minFeePerBlobByte := sdk.ZeroDec()
+	globalMinGasPrice.GetIfExists(ctx, KeyMinFeePerBlobByte, &minFeePerBlobByte)
+
+	return &GenesisState{
+		GlobalMinGasPrice:     minGasPrice,
+		PriorityScalingFactor: priorityScalingFactor,
+		NamespaceMinGasPrices: namespaceMinGasPrices,
+		MinFeePerBlobByte:     minFeePerBlobByte,
+	}
 }
@@ -28,6 +28,15 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 // GenesisState defines the minfee module's genesis state.
 type GenesisState struct {
 	GlobalMinGasPrice github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,1,opt,name=global_min_gas_price,json=globalMinGasPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"global_min_gas_price"`
+	// priority_scaling_factor converts the gas price of a transaction to its
+	// mempool priority. Zero uses the default.
+	PriorityScalingFactor uint64 `protobuf:"varint,2,opt,name=priority_scaling_factor,json=priorityScalingFactor,proto3" json:"priority_scaling_factor,omitempty"`
+	// namespace_min_gas_prices overrides the global minimum gas price for
+	// PayForBlobs transactions that target one of the namespaces.
+	NamespaceMinGasPrices []NamespaceMinGasPrice `protobuf:"bytes,3,rep,name=namespace_min_gas_prices,json=namespaceMinGasPrices,proto3" json:"namespace_min_gas_prices"`
+	// min_fee_per_blob_byte is the minimum fee per byte of blob data paid for
+	// by the PayForBlobs messages of a transaction. Zero disables it.
+	MinFeePerBlobByte github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,4,opt,name=min_fee_per_blob_byte,json=minFeePerBlobByte,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"min_fee_per_blob_byte"`
 }
 
 func (m *GenesisState) Reset()         { *m = GenesisState{} }
@@ -63,30 +72,101 @@ func (m *GenesisState) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_GenesisState proto.InternalMessageInfo
 
+func (m *GenesisState) GetPriorityScalingFactor() uint64 {
+	if m != nil {
+		return m.PriorityScalingFactor
+	}
+	return 0
+}
+
+func (m *GenesisState) GetNamespaceMinGasPrices() []NamespaceMinGasPrice {
+	if m != nil {
+		return m.NamespaceMinGasPrices
+	}
+	return nil
+}
+
+// NamespaceMinGasPrice is the minimum gas price of PayForBlobs transactions
+// that target the namespace.
+type NamespaceMinGasPrice struct {
+	Namespace   []byte                                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	MinGasPrice github_com_cosmos_cosmos_sdk_types.Dec `protobuf:"bytes,2,opt,name=min_gas_price,json=minGasPrice,proto3,customtype=github.com/cosmos/cosmos-sdk/types.Dec" json:"min_gas_price"`
+}
+
+func (m *NamespaceMinGasPrice) Reset()         { *m = NamespaceMinGasPrice{} }
+func (m *NamespaceMinGasPrice) String() string { return proto.CompactTextString(m) }
+func (*NamespaceMinGasPrice) ProtoMessage()    {}
+func (*NamespaceMinGasPrice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_40506204178306cf, []int{1}
+}
+func (m *NamespaceMinGasPrice) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NamespaceMinGasPrice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NamespaceMinGasPrice.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *NamespaceMinGasPrice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NamespaceMinGasPrice.Merge(m, src)
+}
+func (m *NamespaceMinGasPrice) XXX_Size() int {
+	return m.Size()
+}
+func (m *NamespaceMinGasPrice) XXX_DiscardUnknown() {
+	xxx_messageInfo_NamespaceMinGasPrice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NamespaceMinGasPrice proto.InternalMessageInfo
+
+func (m *NamespaceMinGasPrice) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*GenesisState)(nil), "celestia.minfee.v1.GenesisState")
+	proto.RegisterType((*NamespaceMinGasPrice)(nil), "celestia.minfee.v1.NamespaceMinGasPrice")
 }
 
 func init() { proto.RegisterFile("celestia/minfee/v1/genesis.proto", fileDescriptor_40506204178306cf) }
 
 var fileDescriptor_40506204178306cf = []byte{
-	// 249 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x52, 0x48, 0x4e, 0xcd, 0x49,
-	0x2d, 0x2e, 0xc9, 0x4c, 0xd4, 0xcf, 0xcd, 0xcc, 0x4b, 0x4b, 0x4d, 0xd5, 0x2f, 0x33, 0xd4, 0x4f,
-	0x4f, 0xcd, 0x4b, 0x2d, 0xce, 0x2c, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x82, 0xa9,
-	0xd0, 0x83, 0xa8, 0xd0, 0x2b, 0x33, 0x94, 0x12, 0x49, 0xcf, 0x4f, 0xcf, 0x07, 0x4b, 0xeb, 0x83,
-	0x58, 0x10, 0x95, 0x52, 0x92, 0xc9, 0xf9, 0xc5, 0xb9, 0xf9, 0xc5, 0xf1, 0x10, 0x09, 0x08, 0x07,
-	0x22, 0xa5, 0x54, 0xcb, 0xc5, 0xe3, 0x0e, 0x31, 0x35, 0xb8, 0x24, 0xb1, 0x24, 0x55, 0x28, 0x97,
-	0x4b, 0x24, 0x3d, 0x27, 0x3f, 0x29, 0x31, 0x27, 0x3e, 0x37, 0x33, 0x2f, 0x3e, 0x3d, 0x11, 0xa4,
-	0x29, 0x33, 0x39, 0x55, 0x82, 0x51, 0x81, 0x51, 0x83, 0xd3, 0xc9, 0xe6, 0xc4, 0x3d, 0x79, 0x86,
-	0x5b, 0xf7, 0xe4, 0xd5, 0xd2, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3, 0x73, 0xa1, 0xc6,
-	0x41, 0x29, 0xdd, 0xe2, 0x94, 0x6c, 0xfd, 0x92, 0xca, 0x82, 0xd4, 0x62, 0x3d, 0x97, 0xd4, 0xe4,
-	0x4b, 0x5b, 0x74, 0xb9, 0xa0, 0xb6, 0xb9, 0xa4, 0x26, 0x07, 0x09, 0x42, 0x4c, 0xf6, 0xcd, 0xcc,
-	0x73, 0x4f, 0x2c, 0x0e, 0x00, 0x19, 0xeb, 0xe4, 0x76, 0xe2, 0x91, 0x1c, 0xe3, 0x85, 0x47, 0x72,
-	0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0x4e, 0x78, 0x2c, 0xc7, 0x70, 0xe1, 0xb1, 0x1c, 0xc3, 0x8d, 0xc7,
-	0x72, 0x0c, 0x51, 0x3a, 0xc8, 0x56, 0x40, 0x3d, 0x9a, 0x5f, 0x94, 0x0e, 0x67, 0xeb, 0x26, 0x16,
-	0x14, 0xe8, 0x57, 0x40, 0x03, 0x27, 0x89, 0x0d, 0xec, 0x1b, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x61, 0x71, 0xcd, 0x0a, 0x36, 0x01, 0x00, 0x00,
+	// 389 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xad, 0x92, 0xcb, 0x4e, 0xc2, 0x40,
+	0x14, 0x86, 0xa9, 0x10, 0x13, 0x06, 0x5c, 0xd8, 0x40, 0xac, 0xc4, 0x00, 0x61, 0x61, 0x58, 0x48,
+	0x1b, 0x34, 0x71, 0xe5, 0xaa, 0x31, 0xb0, 0xd2, 0x90, 0xb2, 0x73, 0x33, 0x4e, 0xeb, 0x61, 0x6c,
+	0x6c, 0x3b, 0x4d, 0x67, 0x24, 0xf2, 0x12, 0xc6, 0x95, 0x4f, 0xe2, 0x43, 0xb0, 0x24, 0xae, 0x8c,
+	0x0b, 0x62, 0xf4, 0x45, 0x9c, 0x5e, 0x40, 0x88, 0x2c, 0x59, 0x9c, 0xcc, 0xe5, 0x3f, 0xe7, 0x9b,
+	0x7f, 0xce, 0x0c, 0x6a, 0x3a, 0xe0, 0x01, 0x17, 0x2e, 0x31, 0x7c, 0x37, 0x18, 0x01, 0x18, 0xe3,
+	0xae, 0x41, 0x21, 0x00, 0xee, 0x72, 0x3d, 0x8c, 0x98, 0x60, 0xaa, 0xba, 0xc8, 0xd0, 0xd3, 0x0c,
+	0x7d, 0xdc, 0xad, 0x55, 0x28, 0xa3, 0x2c, 0x91, 0x8d, 0x78, 0x96, 0x66, 0xd6, 0x0e, 0x1d, 0xc6,
+	0x7d, 0xc6, 0x71, 0x2a, 0xa4, 0x8b, 0x54, 0x6a, 0x3d, 0xe7, 0x51, 0xb9, 0x9f, 0x62, 0x87, 0x82,
+	0x08, 0x50, 0x7d, 0x54, 0xa1, 0x1e, 0xb3, 0x89, 0x87, 0x25, 0x15, 0x53, 0x12, 0x57, 0xb9, 0x0e,
+	0x68, 0x4a, 0x53, 0x69, 0x17, 0xcd, 0x8b, 0xe9, 0xbc, 0x91, 0xfb, 0x9c, 0x37, 0x8e, 0xa9, 0x2b,
+	0xee, 0x1f, 0x6d, 0xdd, 0x61, 0x7e, 0xc6, 0xcb, 0x86, 0x0e, 0xbf, 0x7b, 0x30, 0xc4, 0x24, 0x04,
+	0xae, 0x5f, 0x82, 0xf3, 0xfe, 0xd6, 0x41, 0xd9, 0x71, 0x72, 0x65, 0xed, 0xa7, 0xe4, 0x2b, 0x37,
+	0xe8, 0x13, 0x3e, 0x88, 0xb1, 0xea, 0x39, 0x3a, 0x90, 0x7c, 0x16, 0xb9, 0x62, 0x82, 0xb9, 0x43,
+	0x3c, 0x37, 0xa0, 0x78, 0x44, 0x1c, 0xc1, 0x22, 0x6d, 0x47, 0x9e, 0x58, 0xb0, 0xaa, 0x0b, 0x79,
+	0x98, 0xaa, 0xbd, 0x44, 0x54, 0x29, 0xd2, 0x02, 0xe2, 0x03, 0x0f, 0x89, 0x03, 0xeb, 0x4e, 0xb9,
+	0x96, 0x6f, 0xe6, 0xdb, 0xa5, 0xd3, 0xb6, 0xfe, 0xbf, 0x3f, 0xfa, 0xf5, 0xa2, 0x66, 0xc5, 0x83,
+	0x59, 0x88, 0x2f, 0x65, 0x55, 0x83, 0x0d, 0x1a, 0x57, 0x03, 0x54, 0x8d, 0xf1, 0xb2, 0x1e, 0x87,
+	0x10, 0x61, 0x5b, 0xde, 0x00, 0xdb, 0x13, 0x01, 0x5a, 0x61, 0x1b, 0x0d, 0x91, 0xe8, 0x1e, 0xc0,
+	0x00, 0x22, 0x53, 0x72, 0x4d, 0x89, 0x6d, 0xbd, 0x2a, 0xa8, 0xb2, 0xc9, 0xa5, 0x7a, 0x84, 0x8a,
+	0x4b, 0x87, 0xc9, 0x6b, 0x94, 0xad, 0xbf, 0x0d, 0xf5, 0x16, 0xed, 0xad, 0xbf, 0xd7, 0xce, 0x16,
+	0xec, 0x95, 0xfc, 0x95, 0x2e, 0xf5, 0xa6, 0xdf, 0x75, 0x65, 0x26, 0xe3, 0x4b, 0xc6, 0xcb, 0x4f,
+	0x3d, 0x37, 0x93, 0xf1, 0x21, 0xe3, 0xe6, 0x64, 0x15, 0x9e, 0xf5, 0x9c, 0x45, 0x74, 0x39, 0xef,
+	0x90, 0x30, 0x34, 0x9e, 0xb2, 0x7f, 0x6c, 0xef, 0x26, 0x1f, 0xef, 0xec, 0x17, 0x2b, 0x95, 0xa3,
+	0x6b, 0xe1, 0x02, 0x00, 0x00,
 }
 
 func (m *GenesisState) Marshal() (dAtA []byte, err error) {
@@ -109,6 +189,35 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	{
+		size := m.MinFeePerBlobByte.Size()
+		i -= size
+		if _, err := m.MinFeePerBlobByte.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	if len(m.NamespaceMinGasPrices) > 0 {
+		for iNdEx := len(m.NamespaceMinGasPrices) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.NamespaceMinGasPrices[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintGenesis(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.PriorityScalingFactor != 0 {
+		i = encodeVarintGenesis(dAtA, i, uint64(m.PriorityScalingFactor))
+		i--
+		dAtA[i] = 0x10
+	}
 	{
 		size := m.GlobalMinGasPrice.Size()
 		i -= size
@@ -122,6 +231,46 @@ func (m *GenesisState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *NamespaceMinGasPrice) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NamespaceMinGasPrice) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NamespaceMinGasPrice) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size := m.MinGasPrice.Size()
+		i -= size
+		if _, err := m.MinGasPrice.MarshalTo(dAtA[i:]); err != nil {
+			return 0, err
+		}
+		i = encodeVarintGenesis(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintGenesis(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintGenesis(dAtA []byte, offset int, v uint64) int {
 	offset -= sovGenesis(v)
 	base := offset
@@ -141,6 +290,32 @@ func (m *GenesisState) Size() (n int) {
 	_ = l
 	l = m.GlobalMinGasPrice.Size()
 	n += 1 + l + sovGenesis(uint64(l))
+	if m.PriorityScalingFactor != 0 {
+		n += 1 + sovGenesis(uint64(m.PriorityScalingFactor))
+	}
+	if len(m.NamespaceMinGasPrices) > 0 {
+		for _, e := range m.NamespaceMinGasPrices {
+			l = e.Size()
+			n += 1 + l + sovGenesis(uint64(l))
+		}
+	}
+	l = m.MinFeePerBlobByte.Size()
+	n += 1 + l + sovGenesis(uint64(l))
+	return n
+}
+
+func (m *NamespaceMinGasPrice) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovGenesis(uint64(l))
+	}
+	l = m.MinGasPrice.Size()
+	n += 1 + l + sovGenesis(uint64(l))
 	return n
 }
 
@@ -213,6 +388,211 @@ func (m *GenesisState) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PriorityScalingFactor", wireType)
+			}
+			m.PriorityScalingFactor = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PriorityScalingFactor |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamespaceMinGasPrices", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamespaceMinGasPrices = append(m.NamespaceMinGasPrices, NamespaceMinGasPrice{})
+			if err := m.NamespaceMinGasPrices[len(m.NamespaceMinGasPrices)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinFeePerBlobByte", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinFeePerBlobByte.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipGenesis(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NamespaceMinGasPrice) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowGenesis
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NamespaceMinGasPrice: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NamespaceMinGasPrice: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = append(m.Namespace[:0], dAtA[iNdEx:postIndex]...)
+			if m.Namespace == nil {
+				m.Namespace = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinGasPrice", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowGenesis
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthGenesis
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinGasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipGenesis(dAtA[iNdEx:])
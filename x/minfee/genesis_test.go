@@ -0,0 +1,119 @@
+package minfee_test
+
+import (
+	"bytes"
+	"testing"
+
+	testutil "github.com/celestiaorg/celestia-app/v2/test/util"
+	"github.com/celestiaorg/celestia-app/v2/x/minfee"
+	ns "github.com/celestiaorg/go-square/namespace"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGenesisNamespaceMinGasPrices(t *testing.T) {
+	namespace := ns.MustNewV0(bytes.Repeat([]byte{1}, ns.NamespaceVersionZeroIDSize)).Bytes()
+
+	testCases := []struct {
+		name      string
+		overrides []minfee.NamespaceMinGasPrice
+		expErr    bool
+	}{
+		{
+			name: "no overrides",
+		},
+		{
+			name:      "valid override",
+			overrides: []minfee.NamespaceMinGasPrice{{Namespace: namespace, MinGasPrice: sdk.NewDecWithPrec(5, 3)}},
+		},
+		{
+			name:      "zero min gas price",
+			overrides: []minfee.NamespaceMinGasPrice{{Namespace: namespace, MinGasPrice: sdk.ZeroDec()}},
+		},
+		{
+			name:      "negative min gas price",
+			overrides: []minfee.NamespaceMinGasPrice{{Namespace: namespace, MinGasPrice: sdk.NewDecWithPrec(-1, 3)}},
+			expErr:    true,
+		},
+		{
+			name:      "unset min gas price",
+			overrides: []minfee.NamespaceMinGasPrice{{Namespace: namespace}},
+			expErr:    true,
+		},
+		{
+			name:      "invalid namespace",
+			overrides: []minfee.NamespaceMinGasPrice{{Namespace: []byte{1, 2, 3}, MinGasPrice: sdk.NewDecWithPrec(5, 3)}},
+			expErr:    true,
+		},
+		{
+			name: "duplicate namespace",
+			overrides: []minfee.NamespaceMinGasPrice{
+				{Namespace: namespace, MinGasPrice: sdk.NewDecWithPrec(5, 3)},
+				{Namespace: namespace, MinGasPrice: sdk.NewDecWithPrec(6, 3)},
+			},
+			expErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			genesis := minfee.DefaultGenesis()
+			genesis.NamespaceMinGasPrices = tc.overrides
+			err := minfee.ValidateGenesis(genesis)
+			if tc.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateGenesisMinFeePerBlobByte(t *testing.T) {
+	genesis := minfee.DefaultGenesis()
+	require.NoError(t, minfee.ValidateGenesis(genesis))
+
+	// an unset min fee per blob byte disables it
+	genesis.MinFeePerBlobByte = sdk.Dec{}
+	require.NoError(t, minfee.ValidateGenesis(genesis))
+
+	genesis.MinFeePerBlobByte = sdk.NewDecWithPrec(-1, 2)
+	require.Error(t, minfee.ValidateGenesis(genesis))
+}
+
+func TestGenesisRoundTrip(t *testing.T) {
+	genesis := minfee.DefaultGenesis()
+	genesis.NamespaceMinGasPrices = []minfee.NamespaceMinGasPrice{
+		{Namespace: ns.MustNewV0(bytes.Repeat([]byte{1}, ns.NamespaceVersionZeroIDSize)).Bytes(), MinGasPrice: sdk.NewDecWithPrec(5, 3)},
+		{Namespace: ns.MustNewV0(bytes.Repeat([]byte{2}, ns.NamespaceVersionZeroIDSize)).Bytes(), MinGasPrice: sdk.NewDecWithPrec(1, 4)},
+	}
+	genesis.MinFeePerBlobByte = sdk.NewDecWithPrec(1, 2)
+	require.NoError(t, minfee.ValidateGenesis(genesis))
+
+	// the genesis state survives encoding
+	bz, err := genesis.Marshal()
+	require.NoError(t, err)
+	var decoded minfee.GenesisState
+	require.NoError(t, decoded.Unmarshal(bz))
+	requireGenesisEqual(t, genesis, &decoded)
+
+	// and initializing and exporting the module
+	paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, false, minfee.Params{})
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+	minfee.NewAppModule(paramsKeeper).InitGenesis(ctx, cdc, cdc.MustMarshalJSON(genesis))
+	requireGenesisEqual(t, genesis, minfee.ExportGenesis(ctx, paramsKeeper))
+}
+
+func requireGenesisEqual(t *testing.T, expected, actual *minfee.GenesisState) {
+	t.Helper()
+	require.True(t, expected.GlobalMinGasPrice.Equal(actual.GlobalMinGasPrice))
+	require.Equal(t, expected.PriorityScalingFactor, actual.PriorityScalingFactor)
+	require.True(t, expected.MinFeePerBlobByte.Equal(actual.MinFeePerBlobByte))
+	require.Len(t, actual.NamespaceMinGasPrices, len(expected.NamespaceMinGasPrices))
+	for i, override := range expected.NamespaceMinGasPrices {
+		require.Equal(t, override.Namespace, actual.NamespaceMinGasPrices[i].Namespace)
+		require.True(t, override.MinGasPrice.Equal(actual.NamespaceMinGasPrices[i].MinGasPrice))
+	}
+}
@@ -0,0 +1,51 @@
+package minfee
+
+import (
+	"context"
+
+	v1 "github.com/celestiaorg/celestia-app/v2/pkg/appconsts/v1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	params "github.com/cosmos/cosmos-sdk/x/params/keeper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ QueryServer = queryServer{}
+
+type queryServer struct {
+	paramsKeeper params.Keeper
+}
+
+// NewQueryServerImpl returns an implementation of the minfee QueryServer that
+// reads the params from the provided keeper.
+func NewQueryServerImpl(paramsKeeper params.Keeper) QueryServer {
+	return queryServer{paramsKeeper: paramsKeeper}
+}
+
+// MinFee returns the minimum gas price and the minimum fee that the ante
+// handler requires of a transaction with the requested gas limit, namespaces
+// and blob bytes. It reads the params and computes the fee with the same
+// functions as the ante handler, including the fallback to the default global
+// minimum gas price if the param is not set. There is no minimum for app
+// version 1.
+func (q queryServer) MinFee(goCtx context.Context, req *QueryMinFeeRequest) (*QueryMinFeeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	ctx := sdk.UnwrapSDKContext(goCtx)
+	if ctx.BlockHeader().Version.App <= v1.Version {
+		return &QueryMinFeeResponse{GlobalMinGasPrice: sdk.ZeroDec(), MinFee: sdk.ZeroInt()}, nil
+	}
+
+	subspace, exists := q.paramsKeeper.GetSubspace(ModuleName)
+	if !exists {
+		return nil, status.Error(codes.Internal, "minfee subspace not set")
+	}
+
+	minGasPrice, minFeePerBlobByte := GlobalMinimums(ctx, subspace, req.Namespaces)
+	return &QueryMinFeeResponse{
+		GlobalMinGasPrice: minGasPrice,
+		MinFee:            RequiredMinFee(minGasPrice, minFeePerBlobByte, req.GasLimit, req.BlobBytes),
+	}, nil
+}
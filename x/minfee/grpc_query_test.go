@@ -0,0 +1,127 @@
+package minfee_test
+
+import (
+	"bytes"
+	"testing"
+
+	testutil "github.com/celestiaorg/celestia-app/v2/test/util"
+	"github.com/celestiaorg/celestia-app/v2/x/minfee"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryMinFee(t *testing.T) {
+	globalMinGasPrice, err := sdk.NewDecFromStr("0.0025")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name           string
+		appVersion     uint64
+		gasLimit       uint64
+		minGasPrice    sdk.Dec
+		expMinGasPrice sdk.Dec
+		expMinFee      sdk.Int
+	}{
+		{
+			name:           "v1 has no global minimum",
+			appVersion:     1,
+			gasLimit:       100_000,
+			minGasPrice:    globalMinGasPrice,
+			expMinGasPrice: sdk.ZeroDec(),
+			expMinFee:      sdk.ZeroInt(),
+		},
+		{
+			name:           "v2 whole fee",
+			appVersion:     2,
+			gasLimit:       100_000,
+			minGasPrice:    globalMinGasPrice,
+			expMinGasPrice: globalMinGasPrice,
+			expMinFee:      sdk.NewInt(250),
+		},
+		{
+			name:           "v2 fractional fee is rounded up",
+			appVersion:     2,
+			gasLimit:       100_001,
+			minGasPrice:    globalMinGasPrice,
+			expMinGasPrice: globalMinGasPrice,
+			expMinFee:      sdk.NewInt(251),
+		},
+		{
+			name:           "v2 global min gas price not set uses the default",
+			appVersion:     2,
+			gasLimit:       100_000,
+			expMinGasPrice: minfee.DefaultGlobalMinGasPrice,
+			expMinFee:      minfee.RequiredFee(minfee.DefaultGlobalMinGasPrice, 100_000),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, tc.appVersion, false, minfee.Params{GlobalMinGasPrice: tc.minGasPrice})
+
+			server := minfee.NewQueryServerImpl(paramsKeeper)
+			resp, err := server.MinFee(sdk.WrapSDKContext(ctx), &minfee.QueryMinFeeRequest{GasLimit: tc.gasLimit})
+			require.NoError(t, err)
+			require.Equal(t, tc.expMinGasPrice, resp.GlobalMinGasPrice)
+			require.Equal(t, tc.expMinFee, resp.MinFee)
+		})
+	}
+}
+
+func TestQueryMinFeePayForBlobs(t *testing.T) {
+	overridden := bytes.Repeat([]byte{1}, 29)
+	other := bytes.Repeat([]byte{2}, 29)
+	gasLimit := uint64(100_000)
+
+	testCases := []struct {
+		name           string
+		namespaces     [][]byte
+		blobBytes      uint64
+		expMinGasPrice sdk.Dec
+		expMinFee      sdk.Int
+	}{
+		{
+			name:           "namespace without override uses the global minimum",
+			namespaces:     [][]byte{other},
+			blobBytes:      1_000,
+			expMinGasPrice: sdk.NewDecWithPrec(1, 3),
+			expMinFee:      sdk.NewInt(100),
+		},
+		{
+			name:           "overridden namespace uses its minimum",
+			namespaces:     [][]byte{other, overridden},
+			blobBytes:      1_000,
+			expMinGasPrice: sdk.NewDecWithPrec(1, 2),
+			expMinFee:      sdk.NewInt(1_000),
+		},
+		{
+			name:           "large blobs pay the min fee per blob byte",
+			namespaces:     [][]byte{other},
+			blobBytes:      1_000_000,
+			expMinGasPrice: sdk.NewDecWithPrec(1, 3),
+			expMinFee:      sdk.NewInt(10_000),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			paramsKeeper, ctx := testutil.SetupMinFeeParams(t, 2, false, minfee.Params{
+				GlobalMinGasPrice: sdk.NewDecWithPrec(1, 3),
+				NamespaceMinGasPrices: []minfee.NamespaceMinGasPrice{
+					{Namespace: overridden, MinGasPrice: sdk.NewDecWithPrec(1, 2)},
+				},
+				MinFeePerBlobByte: sdk.NewDecWithPrec(1, 2),
+			})
+
+			server := minfee.NewQueryServerImpl(paramsKeeper)
+			resp, err := server.MinFee(sdk.WrapSDKContext(ctx), &minfee.QueryMinFeeRequest{
+				GasLimit:   gasLimit,
+				Namespaces: tc.namespaces,
+				BlobBytes:  tc.blobBytes,
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.expMinGasPrice, resp.GlobalMinGasPrice)
+			require.Equal(t, tc.expMinFee, resp.MinFee)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package minfee
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
@@ -55,7 +56,11 @@ func (AppModuleBasic) ValidateGenesis(cdc codec.JSONCodec, _ client.TxEncodingCo
 func (AppModuleBasic) RegisterRESTRoutes(_ client.Context, _ *mux.Router) {}
 
 // RegisterGRPCGatewayRoutes registers the gRPC Gateway routes for the module.
-func (AppModuleBasic) RegisterGRPCGatewayRoutes(_ client.Context, _ *runtime.ServeMux) {}
+func (AppModuleBasic) RegisterGRPCGatewayRoutes(clientCtx client.Context, mux *runtime.ServeMux) {
+	if err := RegisterQueryHandlerClient(context.Background(), mux, NewQueryClient(clientCtx)); err != nil {
+		panic(err)
+	}
+}
 
 // GetTxCmd returns the minfee module's root tx command.
 func (a AppModuleBasic) GetTxCmd() *cobra.Command {
@@ -101,8 +106,11 @@ func (am AppModule) LegacyQuerierHandler(_ *codec.LegacyAmino) sdk.Querier {
 	return nil
 }
 
-// RegisterServices registers module services.
-func (am AppModule) RegisterServices(_ sdkmodule.Configurator) {}
+// RegisterServices registers a GRPC query service to respond to the
+// module-specific GRPC queries.
+func (am AppModule) RegisterServices(cfg sdkmodule.Configurator) {
+	RegisterQueryServer(cfg.QueryServer(), NewQueryServerImpl(am.paramsKeeper))
+}
 
 // InitGenesis performs genesis initialization for the minfee module. It returns no validator updates.
 func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, gs json.RawMessage) []abci.ValidatorUpdate {
@@ -122,7 +130,22 @@ func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, gs json.Ra
 		panic("failed to convert GlobalMinGasPrice to sdk.Dec")
 	}
 
-	subspace.SetParamSet(ctx, &Params{GlobalMinGasPrice: globalMinGasPriceDec})
+	priorityScalingFactor := genesisState.PriorityScalingFactor
+	if priorityScalingFactor == 0 {
+		priorityScalingFactor = DefaultPriorityScalingFactor
+	}
+
+	minFeePerBlobByte := genesisState.MinFeePerBlobByte
+	if minFeePerBlobByte.IsNil() {
+		minFeePerBlobByte = sdk.ZeroDec()
+	}
+
+	subspace.SetParamSet(ctx, &Params{
+		GlobalMinGasPrice:     globalMinGasPriceDec,
+		PriorityScalingFactor: priorityScalingFactor,
+		NamespaceMinGasPrices: genesisState.NamespaceMinGasPrices,
+		MinFeePerBlobByte:     minFeePerBlobByte,
+	})
 
 	return []abci.ValidatorUpdate{}
 }
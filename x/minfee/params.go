@@ -1,19 +1,29 @@
 package minfee
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 
 	"github.com/celestiaorg/celestia-app/v2/pkg/appconsts"
+	ns "github.com/celestiaorg/go-square/namespace"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 )
 
 const ModuleName = "minfee"
 
+// DefaultPriorityScalingFactor is the default factor used to convert the gas
+// price of a transaction to its mempool priority.
+const DefaultPriorityScalingFactor = 1_000_000
+
 var _ paramtypes.ParamSet = (*Params)(nil)
 
 var (
 	KeyGlobalMinGasPrice     = []byte("GlobalMinGasPrice")
+	KeyPriorityScalingFactor = []byte("PriorityScalingFactor")
+	KeyNamespaceMinGasPrices = []byte("NamespaceMinGasPrices")
+	KeyMinFeePerBlobByte     = []byte("MinFeePerBlobByte")
 	DefaultGlobalMinGasPrice sdk.Dec
 )
 
@@ -26,7 +36,10 @@ func init() {
 }
 
 type Params struct {
-	GlobalMinGasPrice sdk.Dec
+	GlobalMinGasPrice     sdk.Dec
+	PriorityScalingFactor uint64
+	NamespaceMinGasPrices []NamespaceMinGasPrice
+	MinFeePerBlobByte     sdk.Dec
 }
 
 // RegisterMinFeeParamTable attaches a key table to the provided subspace if it doesn't have one
@@ -45,6 +58,9 @@ func ParamKeyTable() paramtypes.KeyTable {
 func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
 	return paramtypes.ParamSetPairs{
 		paramtypes.NewParamSetPair(KeyGlobalMinGasPrice, &p.GlobalMinGasPrice, ValidateMinGasPrice),
+		paramtypes.NewParamSetPair(KeyPriorityScalingFactor, &p.PriorityScalingFactor, ValidatePriorityScalingFactor),
+		paramtypes.NewParamSetPair(KeyNamespaceMinGasPrices, &p.NamespaceMinGasPrices, ValidateNamespaceMinGasPrices),
+		paramtypes.NewParamSetPair(KeyMinFeePerBlobByte, &p.MinFeePerBlobByte, ValidateMinFeePerBlobByte),
 	}
 }
 
@@ -57,3 +73,59 @@ func ValidateMinGasPrice(i interface{}) error {
 
 	return nil
 }
+
+// ValidatePriorityScalingFactor validates that the scaling factor is positive
+// and fits in an int64 as priorities are int64.
+func ValidatePriorityScalingFactor(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == 0 || v > math.MaxInt64 {
+		return fmt.Errorf("priority scaling factor must be between 1 and %d: %d", int64(math.MaxInt64), v)
+	}
+
+	return nil
+}
+
+// ValidateNamespaceMinGasPrices validates that each override targets a valid
+// namespace, that no namespace is overridden twice and that no minimum gas
+// price is negative.
+func ValidateNamespaceMinGasPrices(i interface{}) error {
+	v, ok := i.([]NamespaceMinGasPrice)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for idx, override := range v {
+		if _, err := ns.From(override.Namespace); err != nil {
+			return fmt.Errorf("invalid namespace %X: %w", override.Namespace, err)
+		}
+		if override.MinGasPrice.IsNil() || override.MinGasPrice.IsNegative() {
+			return fmt.Errorf("min gas price of namespace %X cannot be negative: %v", override.Namespace, override.MinGasPrice)
+		}
+		for _, other := range v[:idx] {
+			if bytes.Equal(other.Namespace, override.Namespace) {
+				return fmt.Errorf("duplicate min gas price for namespace %X", override.Namespace)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateMinFeePerBlobByte validates that the minimum fee per blob byte is not
+// negative. Zero disables the blob size based minimum fee.
+func ValidateMinFeePerBlobByte(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("min fee per blob byte cannot be negative: %v", v)
+	}
+
+	return nil
+}
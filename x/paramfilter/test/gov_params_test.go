@@ -1,6 +1,9 @@
 package test
 
 import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
 	"testing"
 	"time"
 
@@ -14,6 +17,7 @@ import (
 	blobtypes "github.com/celestiaorg/celestia-app/v2/x/blob/types"
 	bsmoduletypes "github.com/celestiaorg/celestia-app/v2/x/blobstream/types"
 	minfeetypes "github.com/celestiaorg/celestia-app/v2/x/minfee"
+	ns "github.com/celestiaorg/go-square/namespace"
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
@@ -54,6 +58,7 @@ func TestGovParamsTestSuite(t *testing.T) {
 // in the specs params.md file are modifiable via governance.
 func (suite *GovParamsTestSuite) TestModifiableParams() {
 	assert := suite.Assert()
+	namespace := ns.MustNewV0(bytes.Repeat([]byte{1}, ns.NamespaceVersionZeroIDSize)).Bytes()
 
 	testCases := []struct {
 		name         string
@@ -504,6 +509,55 @@ func (suite *GovParamsTestSuite) TestModifiableParams() {
 				assert.Equal(want, got)
 			},
 		},
+		{
+			"minfee.PriorityScalingFactor",
+			testProposal(proposal.ParamChange{
+				Subspace: minfeetypes.ModuleName,
+				Key:      string(minfeetypes.KeyPriorityScalingFactor),
+				Value:    `"1000"`,
+			}),
+			func() {
+				var got uint64
+				subspace := suite.app.GetSubspace(minfeetypes.ModuleName)
+				subspace.Get(suite.ctx, minfeetypes.KeyPriorityScalingFactor, &got)
+
+				want := uint64(1000)
+				assert.Equal(want, got)
+			},
+		},
+		{
+			"minfee.MinFeePerBlobByte",
+			testProposal(proposal.ParamChange{
+				Subspace: minfeetypes.ModuleName,
+				Key:      string(minfeetypes.KeyMinFeePerBlobByte),
+				Value:    `"0.01"`,
+			}),
+			func() {
+				var got sdk.Dec
+				subspace := suite.app.GetSubspace(minfeetypes.ModuleName)
+				subspace.Get(suite.ctx, minfeetypes.KeyMinFeePerBlobByte, &got)
+
+				want, err := sdk.NewDecFromStr("0.01")
+				assert.NoError(err)
+				assert.Equal(want, got)
+			},
+		},
+		{
+			"minfee.NamespaceMinGasPrices",
+			testProposal(proposal.ParamChange{
+				Subspace: minfeetypes.ModuleName,
+				Key:      string(minfeetypes.KeyNamespaceMinGasPrices),
+				Value:    fmt.Sprintf(`[{"namespace":"%s","min_gas_price":"0.01"}]`, base64.StdEncoding.EncodeToString(namespace)),
+			}),
+			func() {
+				var got []minfeetypes.NamespaceMinGasPrice
+				subspace := suite.app.GetSubspace(minfeetypes.ModuleName)
+				subspace.Get(suite.ctx, minfeetypes.KeyNamespaceMinGasPrices, &got)
+
+				want := []minfeetypes.NamespaceMinGasPrice{{Namespace: namespace, MinGasPrice: sdk.NewDecWithPrec(1, 2)}}
+				assert.Equal(want, got)
+			},
+		},
 	}
 
 	for _, tc := range testCases {